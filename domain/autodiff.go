@@ -0,0 +1,301 @@
+package domain
+
+//Monotonicity describes behavior of expression in one variable on variable domains
+type Monotonicity byte
+
+const (
+	//NotMonotonic means that partial derivative may change its sign or monotonicity can not be proved
+	NotMonotonic Monotonicity = iota
+	//Increasing means that expression is nondecreasing in variable
+	Increasing
+	//Decreasing means that expression is nonincreasing in variable
+	Decreasing
+	//Constant means that expression does not depend on variable
+	Constant
+)
+
+//String returns string representation of monotonicity
+func (m Monotonicity) String() string {
+	switch m {
+	case Increasing:
+		return "Increasing"
+	case Decreasing:
+		return "Decreasing"
+	case Constant:
+		return "Constant"
+	}
+	return "NotMonotonic"
+}
+
+//Gradient is range of expression on variable domains with enclosures of its partial derivatives on them
+type Gradient struct {
+	Value    Interval
+	Partials map[string]Interval
+}
+
+//Monotonicity returns monotonicity of expression in variable name on variable domains.
+//Expression with unbounded or undefined range may have poles in domains, so it is never proved to be monotonic
+func (g Gradient) Monotonicity(name string) Monotonicity {
+	value, ok := g.Value.op.(constInterval)
+	if !ok || value.absorbing() || !value.isBounded() {
+		return NotMonotonic
+	}
+	partial, ok := g.Partials[name].op.(constInterval)
+	if !ok || partial.absorbing() {
+		return NotMonotonic
+	}
+	switch {
+	case partial.isZero():
+		return Constant
+	case partial.left.sign() >= 0:
+		return Increasing
+	case partial.right.sign() <= 0:
+		return Decreasing
+	}
+	return NotMonotonic
+}
+
+//EvalGradient evaluates interval with variable values passed in VarMap using forward-mode automatic differentiation:
+//pairs of value and gradient are propagated through expression in a single pass,
+//so result contains range of expression and enclosures of partial derivatives by all its variables.
+//Unions of intervals are replaced with their hulls. Derivative of abs is sign of argument, derivative of sign
+//is [0, Inf] if argument contains 0, as sign has jump there, and [0, 0] otherwise.
+//Returns the same errors as Eval
+func (i Interval) EvalGradient(varMap VarMap) (Gradient, error) {
+	if err := i.checkVars(varMap); err != nil {
+		return Gradient{}, err
+	}
+	vars := i.Vars()
+	f := forward{index: map[string]int{}}
+	for j, name := range vars {
+		domain, ok := enclosure(varMap[name].Solve(VarMap{}).op)
+		if !ok {
+			//bound value itself contains variables
+			return Gradient{}, &VarError{Unbound: varMap[name].Vars()}
+		}
+		f.index[name] = j
+		f.domains = append(f.domains, domain)
+	}
+	res := f.eval(i.op)
+	g := Gradient{Value: Interval{op: res.value}, Partials: map[string]Interval{}}
+	for j, name := range vars {
+		g.Partials[name] = Interval{op: res.grad[j]}
+	}
+	return g, nil
+}
+
+//EvalMonotonic evaluates interval like Eval, but exploits monotonicity detected with EvalGradient:
+//lower bound is computed with every monotonic variable fixed at its bound minimizing expression
+//and upper bound with every such variable fixed at its bound maximizing it.
+//Result is intersected with range computed by EvalGradient. Returns the same errors as Eval
+func (i Interval) EvalMonotonic(varMap VarMap) (Interval, error) {
+	g, err := i.EvalGradient(varMap)
+	if err != nil {
+		return Interval{}, err
+	}
+	lower, upper := VarMap{}, VarMap{}
+	for name, value := range varMap {
+		lower[name], upper[name] = value, value
+	}
+	for name := range g.Partials {
+		x, ok := varMap[name].Solve(VarMap{}).op.(constInterval)
+		if !ok || x.absorbing() || !x.isBounded() {
+			continue
+		}
+		switch g.Monotonicity(name) {
+		case Increasing:
+			lower[name], upper[name] = Interval{op: point(x.left)}, Interval{op: point(x.right)}
+		case Decreasing:
+			lower[name], upper[name] = Interval{op: point(x.right)}, Interval{op: point(x.left)}
+		case Constant:
+			lower[name], upper[name] = Interval{op: point(x.left)}, Interval{op: point(x.left)}
+		}
+	}
+	//bounds of domains may lie outside of domain of function
+	lo, loOk := i.Solve(lower).op.(constInterval)
+	hi, hiOk := i.Solve(upper).op.(constInterval)
+	if !loOk || !hiOk || lo.absorbing() || hi.absorbing() {
+		return g.Value, nil
+	}
+	return g.Value.Intersect(Interval{op: constInterval{lo.left, hi.right}})
+}
+
+//enclosure returns constant interval containing solved constant interval or union of intervals
+func enclosure(op operation) (constInterval, bool) {
+	switch o := op.(type) {
+	case constInterval:
+		return o, true
+	case multiInterval:
+		return constInterval{o[0].left, o[len(o)-1].right}, true
+	}
+	return constInterval{}, false
+}
+
+//forward propagates values and gradients through expression, index maps variable name to its position in gradient
+type forward struct {
+	index   map[string]int
+	domains []constInterval
+}
+
+//dual is enclosure of value of expression with enclosures of its partial derivatives
+type dual struct {
+	value constInterval
+	grad  []constInterval
+}
+
+func (f forward) constant(c constInterval) dual {
+	res := dual{value: c, grad: make([]constInterval, len(f.domains))}
+	for j := range res.grad {
+		res.grad[j] = add{}.neutral()
+	}
+	return res
+}
+
+func (f forward) eval(op operation) dual {
+	switch o := op.(type) {
+	case constInterval:
+		return f.constant(o)
+	case multiInterval:
+		c, _ := enclosure(o)
+		return f.constant(c)
+	case variable:
+		j := f.index[o.varName]
+		res := f.constant(f.domains[j])
+		res.grad[j] = mul{}.neutral()
+		return res
+	case add:
+		res := f.constant(o.m)
+		for _, operand := range o.operands {
+			res = res.add(f.eval(operand))
+		}
+		for _, operand := range o.invOperands {
+			res = res.sub(f.eval(operand))
+		}
+		return res
+	case mul:
+		res := f.constant(o.k)
+		for _, operand := range o.operands {
+			res = res.mul(f.eval(operand))
+		}
+		for _, operand := range o.invOperands {
+			res = res.div(f.eval(operand))
+		}
+		return res
+	case pow:
+		u := f.eval(o.base)
+		if o.n == 0 {
+			return f.constant(u.value.powConst(0))
+		}
+		n := point(NewInt(int64(o.n)))
+		return u.chain(u.value.powConst(o.n), n.mulConst(u.value.powConst(o.n-1)))
+	case root:
+		u := f.eval(o.arg)
+		v := u.value.rootConst(o.n, o.denom)
+		n := point(NewInt(int64(o.n)))
+		return u.chain(v, mul{}.neutral().divConst(n.mulConst(v.powConst(o.n-1))))
+	case function:
+		return f.eval(o.arg).apply(o.kind)
+	case extremum:
+		res := f.eval(o.operands[0])
+		for _, operand := range o.operands[1:] {
+			res = res.extremum(f.eval(operand), o.max)
+		}
+		return res
+	case clamp:
+		return f.eval(o.x).extremum(f.eval(o.lo), true).extremum(f.eval(o.hi), false)
+	}
+	panic("unknown operation " + op.String())
+}
+
+func (a dual) add(b dual) dual {
+	res := dual{value: a.value.addConst(b.value), grad: make([]constInterval, len(a.grad))}
+	for j := range res.grad {
+		res.grad[j] = a.grad[j].addConst(b.grad[j])
+	}
+	return res
+}
+
+func (a dual) sub(b dual) dual {
+	res := dual{value: a.value.subConst(b.value), grad: make([]constInterval, len(a.grad))}
+	for j := range res.grad {
+		res.grad[j] = a.grad[j].subConst(b.grad[j])
+	}
+	return res
+}
+
+//mul uses (a * b)' = a' * b + a * b'
+func (a dual) mul(b dual) dual {
+	res := dual{value: a.value.mulConst(b.value), grad: make([]constInterval, len(a.grad))}
+	for j := range res.grad {
+		res.grad[j] = a.grad[j].mulConst(b.value).addConst(a.value.mulConst(b.grad[j]))
+	}
+	return res
+}
+
+//div uses (a / b)' = (a' - a / b * b') / b
+func (a dual) div(b dual) dual {
+	res := dual{value: a.value.divConst(b.value), grad: make([]constInterval, len(a.grad))}
+	for j := range res.grad {
+		res.grad[j] = a.grad[j].subConst(res.value.mulConst(b.grad[j])).divConst(b.value)
+	}
+	return res
+}
+
+//chain returns dual with passed value of f(a) and gradient f'(a) * a' by chain rule
+func (a dual) chain(value, derivative constInterval) dual {
+	res := dual{value: value, grad: make([]constInterval, len(a.grad))}
+	for j := range res.grad {
+		res.grad[j] = derivative.mulConst(a.grad[j])
+	}
+	return res
+}
+
+func (a dual) apply(kind funcKind) dual {
+	u, v := a.value, a.value.applyConst(kind)
+	one := mul{}.neutral()
+	switch kind {
+	case funcExp:
+		return a.chain(v, v)
+	case funcLog:
+		return a.chain(v, one.divConst(u))
+	case funcSin:
+		return a.chain(v, u.applyConst(funcCos))
+	case funcCos:
+		return a.chain(v, negConst(u.applyConst(funcSin)))
+	case funcTan:
+		return a.chain(v, one.addConst(v.powConst(2)))
+	case funcAtan:
+		return a.chain(v, one.divConst(one.addConst(u.powConst(2))))
+	case funcAbs:
+		return a.chain(v, u.applyConst(funcSign))
+	case funcSign:
+		if u.contains(Zero()) {
+			return a.chain(v, constInterval{Zero(), Inf()})
+		}
+		return a.chain(v, add{}.neutral())
+	}
+	panic("unknown function " + kind.String())
+}
+
+//extremum returns maximum of a and b if max is true, else their minimum.
+//Gradient is gradient of operand which is proved to be extremum, else it is hull of both gradients
+func (a dual) extremum(b dual, max bool) dual {
+	res := dual{value: a.value.minConst(b.value), grad: make([]constInterval, len(a.grad))}
+	aWins := a.value.right.cmp(b.value.left) < 0
+	bWins := b.value.right.cmp(a.value.left) < 0
+	if max {
+		res.value = a.value.maxConst(b.value)
+		aWins, bWins = bWins, aWins
+	}
+	for j := range res.grad {
+		switch {
+		case aWins:
+			res.grad[j] = a.grad[j]
+		case bWins:
+			res.grad[j] = b.grad[j]
+		default:
+			res.grad[j] = a.grad[j].hull(b.grad[j])
+		}
+	}
+	return res
+}
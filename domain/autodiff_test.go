@@ -0,0 +1,159 @@
+package domain
+
+import "testing"
+
+func TestIntervalEvalGradient(t *testing.T) {
+	var testPairs = []struct {
+		expr         string
+		varMap       VarMap
+		value        string
+		partials     map[string]string
+		monotonicity map[string]Monotonicity
+	}{
+		{
+			expr:         "x * x - x",
+			varMap:       VarMap{"x": NewInterval(NewInt(1), NewInt(2))},
+			value:        "[-1, 3]",
+			partials:     map[string]string{"x": "[1, 3]"},
+			monotonicity: map[string]Monotonicity{"x": Increasing},
+		},
+		{
+			expr:         "x * y - y",
+			varMap:       VarMap{"x": NewInterval(NewInt(2), NewInt(3)), "y": NewInterval(NewInt(1), NewInt(2))},
+			value:        "[0, 5]",
+			partials:     map[string]string{"x": "[1, 2]", "y": "[1, 2]"},
+			monotonicity: map[string]Monotonicity{"x": Increasing, "y": Increasing},
+		},
+		{
+			expr:         "x / (x + 1)",
+			varMap:       VarMap{"x": NewInterval(NewInt(1), NewInt(2))},
+			value:        "[1 / 3, 1]",
+			partials:     map[string]string{"x": "[0, 1 / 3]"},
+			monotonicity: map[string]Monotonicity{"x": Increasing},
+		},
+		{
+			expr:         "x^3 - 3 * x",
+			varMap:       VarMap{"x": NewInterval(NewInt(-1), NewInt(1))},
+			value:        "[-4, 4]",
+			partials:     map[string]string{"x": "[-3, 0]"},
+			monotonicity: map[string]Monotonicity{"x": Decreasing},
+		},
+		{
+			expr:         "max(x, 2) - min(y, 1) + abs(x - 5) + clamp(y, 0, 3)",
+			varMap:       VarMap{"x": NewInterval(NewInt(3), NewInt(4)), "y": NewInterval(NewInt(1), NewInt(2))},
+			value:        "[4, 7]",
+			partials:     map[string]string{"x": "[0, 0]", "y": "[0, 1]"},
+			monotonicity: map[string]Monotonicity{"x": Constant, "y": Increasing},
+		},
+		{
+			expr:         "0 - sign(x)",
+			varMap:       VarMap{"x": NewInterval(NewInt(-1), NewInt(1))},
+			value:        "[-1, 1]",
+			partials:     map[string]string{"x": "[-Inf, 0]"},
+			monotonicity: map[string]Monotonicity{"x": Decreasing},
+		},
+		{
+			expr:         "x * y",
+			varMap:       VarMap{"x": NewInterval(NewInt(-1), NewInt(1)), "y": NewInterval(NewInt(1), NewInt(2))},
+			value:        "[-2, 2]",
+			partials:     map[string]string{"x": "[1, 2]", "y": "[-1, 1]"},
+			monotonicity: map[string]Monotonicity{"x": Increasing, "y": NotMonotonic},
+		},
+		{
+			expr:         "[1, 1] / x",
+			varMap:       VarMap{"x": NewInterval(NewInt(-1), NewInt(1))},
+			value:        "[-Inf, Inf]",
+			partials:     map[string]string{"x": "[-Inf, Inf]"},
+			monotonicity: map[string]Monotonicity{"x": NotMonotonic},
+		},
+		{
+			expr:         "[2, 3]",
+			varMap:       VarMap{},
+			value:        "[2, 3]",
+			partials:     map[string]string{},
+			monotonicity: map[string]Monotonicity{},
+		},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		g, err := expr.EvalGradient(pair.varMap)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := g.Value.String(); res != pair.value {
+			t.Errorf("In pair %d: %s should be evaluated to %s, got %s", i, pair.expr, pair.value, res)
+		}
+		if len(g.Partials) != len(pair.partials) {
+			t.Errorf("In pair %d: %s should have %d partials, got %v", i, pair.expr, len(pair.partials), g.Partials)
+		}
+		for name, partial := range pair.partials {
+			if res := g.Partials[name].String(); res != partial {
+				t.Errorf("In pair %d: partial of %s by %s should be %s, got %s", i, pair.expr, name, partial, res)
+			}
+		}
+		for name, monotonicity := range pair.monotonicity {
+			if res := g.Monotonicity(name); res != monotonicity {
+				t.Errorf("In pair %d: %s should be %s in %s, got %s", i, pair.expr, monotonicity, name, res)
+			}
+		}
+	}
+}
+
+func TestIntervalEvalMonotonic(t *testing.T) {
+	var testPairs = []struct {
+		expr   string
+		varMap VarMap
+		res    string
+	}{
+		{expr: "x * x - x", varMap: VarMap{"x": NewInterval(NewInt(1), NewInt(2))}, res: "[0, 2]"},
+		{
+			expr:   "x * y - y",
+			varMap: VarMap{"x": NewInterval(NewInt(2), NewInt(3)), "y": NewInterval(NewInt(1), NewInt(2))},
+			res:    "[1, 4]",
+		},
+		{expr: "x / (x + 1)", varMap: VarMap{"x": NewInterval(NewInt(1), NewInt(2))}, res: "[1 / 2, 2 / 3]"},
+		{
+			expr:   "x + x / (x + [5, 6]) * [1 / 2, 5 / 3] / x - 1",
+			varMap: VarMap{"x": NewInterval(NewInt(1), NewInt(2))},
+			res:    "[1 / 14, 26 / 21]",
+		},
+		{
+			expr:   "max(x, 2) - min(y, 1) + abs(x - 5) + clamp(y, 0, 3)",
+			varMap: VarMap{"x": NewInterval(NewInt(3), NewInt(4)), "y": NewInterval(NewInt(1), NewInt(2))},
+			res:    "[5, 6]",
+		},
+		{expr: "0 - sign(x)", varMap: VarMap{"x": NewInterval(NewInt(-1), NewInt(1))}, res: "[-1, 1]"},
+		{expr: "x * x", varMap: VarMap{"x": NewInterval(NewInt(-1), NewInt(1))}, res: "[-1, 1]"},
+		{expr: "[1, 1] / x", varMap: VarMap{"x": NewInterval(NewInt(-1), NewInt(1))}, res: "[-Inf, Inf]"},
+		{expr: "sqrt(x)", varMap: VarMap{"x": NewInterval(NewInt(-1), NewInt(4))}, res: "[0, 2]"},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res, err := expr.EvalMonotonic(pair.varMap); err != nil || res.String() != pair.res {
+			t.Errorf("In pair %d: %s should be evaluated to %s, got %s, %v", i, pair.expr, pair.res, res, err)
+		}
+	}
+}
+
+func TestIntervalEvalGradientError(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	if _, err := x.Mul(x).EvalGradient(VarMap{"y": NewInterval(NewInt(1), NewInt(2))}); err == nil {
+		t.Errorf("Gradient of %s with unbound x should return error", x.Mul(x))
+	}
+	if _, err := x.Mul(x).EvalGradient(VarMap{"x": y}); err == nil {
+		t.Errorf("Gradient of %s with x bound to y should return error", x.Mul(x))
+	}
+	if _, err := x.Mul(x).EvalMonotonic(VarMap{}); err == nil {
+		t.Errorf("Monotonic evaluation of %s with unbound x should return error", x.Mul(x))
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+//EvalCentered evaluates interval like Eval and also computes its mean-value form
+//f(c) + f'x(X) * (X - cx) + f'y(Y) * (Y - cy) + ..., where c are midpoints of variable domains X, Y, ...
+//and derivatives are enclosed by solving Derive on the domains. Result is intersection of both forms,
+//so it is never wider than Eval and is much tighter on narrow domains of repeated variables.
+//Result of Eval is returned as is if some domain is unbounded or is a union of intervals
+//or if interval contains discontinuous sign function, which has no mean-value form.
+//Returns the same errors as Eval
+func (i Interval) EvalCentered(varMap VarMap) (Interval, error) {
+	natural, err := i.Eval(varMap)
+	if err != nil {
+		return Interval{}, err
+	}
+	vars := i.Vars()
+	if len(vars) == 0 || !continuous(i.op) {
+		return natural, nil
+	}
+	center := VarMap{}
+	for _, name := range vars {
+		x, ok := varMap[name].op.(constInterval)
+		if !ok || x.absorbing() || !x.isBounded() {
+			return natural, nil
+		}
+		center[name] = Interval{op: point(x.mid())}
+	}
+	res := i.Solve(center)
+	for _, name := range vars {
+		res = res.Add(i.Derive(name).Solve(varMap).Mul(varMap[name].Sub(center[name])))
+	}
+	//center or part of domain may lie outside of domain of function
+	if c, ok := res.Solve(VarMap{}).op.(constInterval); ok && !c.absorbing() {
+		if centered, err := natural.Intersect(Interval{op: c}); err == nil {
+			return centered, nil
+		}
+	}
+	return natural, nil
+}
+
+//continuous returns false if op contains sign function
+func continuous(op operation) bool {
+	if f, ok := op.(function); ok && f.kind == funcSign {
+		return false
+	}
+	for _, child := range op.children() {
+		if !continuous(child) {
+			return false
+		}
+	}
+	return true
+}
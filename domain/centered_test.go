@@ -0,0 +1,93 @@
+package domain
+
+import "testing"
+
+func TestIntervalEvalCentered(t *testing.T) {
+	var testPairs = []struct {
+		expr     string
+		varMap   VarMap
+		natural  string
+		centered string
+	}{
+		{
+			expr:     "x * x - x",
+			varMap:   VarMap{"x": NewInterval(NewFrac(2, 5), NewFrac(3, 5))},
+			natural:  "[-11 / 25, -1 / 25]",
+			centered: "[-27 / 100, -23 / 100]",
+		},
+		{
+			expr:     "x * (1 - x)",
+			varMap:   VarMap{"x": NewInterval(NewFrac(1, 4), NewFrac(3, 4))},
+			natural:  "[1 / 16, 9 / 16]",
+			centered: "[1 / 8, 3 / 8]",
+		},
+		{
+			expr:     "x * x - x",
+			varMap:   VarMap{"x": NewInterval(NewInt(0), NewInt(1))},
+			natural:  "[-1, 1]",
+			centered: "[-3 / 4, 1 / 4]",
+		},
+		{
+			expr:     "x * y - y * x + y",
+			varMap:   VarMap{"x": NewInterval(NewInt(1), NewInt(3)), "y": NewInterval(NewInt(2), NewInt(4))},
+			natural:  "[-8, 14]",
+			centered: "[2, 4]",
+		},
+		{
+			expr:     "x + x / (x + [5, 6]) * [1 / 2, 5 / 3] / x - 1",
+			varMap:   VarMap{"x": NewInterval(NewInt(1), NewInt(2))},
+			natural:  "[1 / 32, 14 / 9]",
+			centered: "[1 / 32, 41207 / 29952]",
+		},
+		{
+			expr:     "sign(x)",
+			varMap:   VarMap{"x": NewInterval(NewInt(-1), NewInt(1))},
+			natural:  "[-1, 1]",
+			centered: "[-1, 1]",
+		},
+		{
+			expr:     "abs(x)",
+			varMap:   VarMap{"x": NewInterval(NewInt(-1), NewInt(1))},
+			natural:  "[0, 1]",
+			centered: "[0, 1]",
+		},
+		{
+			expr:     "[1, 1] / x",
+			varMap:   VarMap{"x": NewInterval(NewInt(-1), NewInt(1))},
+			natural:  "[-Inf, Inf]",
+			centered: "[-Inf, Inf]",
+		},
+		{
+			expr:     "x * x - x",
+			varMap:   VarMap{"x": NewInterval(NewInt(0), Inf())},
+			natural:  "[-Inf, Inf]",
+			centered: "[-Inf, Inf]",
+		},
+		{
+			expr:     "[2, 3]",
+			varMap:   VarMap{},
+			natural:  "[2, 3]",
+			centered: "[2, 3]",
+		},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res, err := expr.Eval(pair.varMap); err != nil || res.String() != pair.natural {
+			t.Errorf("In pair %d: %s should be evaluated to %s, got %s, %v", i, pair.expr, pair.natural, res, err)
+		}
+		if res, err := expr.EvalCentered(pair.varMap); err != nil || res.String() != pair.centered {
+			t.Errorf("In pair %d: centered form of %s should be %s, got %s, %v", i, pair.expr, pair.centered, res, err)
+		}
+	}
+}
+
+func TestIntervalEvalCenteredError(t *testing.T) {
+	x, _ := Var("x")
+	if _, err := x.Mul(x).EvalCentered(VarMap{"y": NewInterval(NewInt(1), NewInt(2))}); err == nil {
+		t.Errorf("Centered form of %s with unbound x should return error", x.Mul(x))
+	}
+}
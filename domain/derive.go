@@ -0,0 +1,111 @@
+package domain
+
+//Derive returns derivative of interval by variable varName as new simplified expression, which can be solved with Solve.
+//Constant intervals and other variables have zero derivative. Piecewise functions are derived where derivative exists:
+//abs(u)' = sign(u) * u', sign(u)' = [0, 0], max(a, b) = (a + b + abs(a - b)) / 2, min(a, b) = (a + b - abs(a - b)) / 2
+//and clamp(x, lo, hi) = min(max(x, lo), hi)
+func (i Interval) Derive(varName string) Interval {
+	return derive(i.op, varName).Simplify()
+}
+
+func derive(op operation, name string) Interval {
+	switch o := op.(type) {
+	case variable:
+		if o.varName == name {
+			return integer(1)
+		}
+	case add:
+		res := integer(0)
+		for _, operand := range o.operands {
+			res = res.Add(derive(operand, name))
+		}
+		for _, operand := range o.invOperands {
+			res = res.Sub(derive(operand, name))
+		}
+		return res
+	case mul:
+		return o.derive(name)
+	case pow:
+		if o.n == 0 {
+			break
+		}
+		base := Interval{op: o.base}
+		return integer(int64(o.n)).Mul(base.Pow(o.n-1), derive(o.base, name))
+	case root:
+		return derive(o.arg, name).Div(integer(int64(o.n)).Mul(Interval{op: o}.Pow(o.n - 1)))
+	case function:
+		return o.derive(name)
+	case extremum:
+		return o.derive(name)
+	case clamp:
+		return derive(Min(Max(Interval{op: o.x}, Interval{op: o.lo}), Interval{op: o.hi}).op, name)
+	}
+	return integer(0)
+}
+
+//derive uses product rule, factor v of invOperands is 1 / v with derivative -v' / v^2
+func (o mul) derive(name string) Interval {
+	res := integer(0)
+	factors := o.children()
+	for j, factor := range factors {
+		term := mul{k: o.k, operands: []operation{derive(factor, name).op}}
+		for l, other := range factors {
+			switch {
+			case l == j:
+			case l < len(o.operands):
+				term.operands = append(term.operands, other)
+			default:
+				term.invOperands = append(term.invOperands, other)
+			}
+		}
+		if j < len(o.operands) {
+			res = res.Add(Interval{op: term})
+			continue
+		}
+		term.invOperands = append(term.invOperands, Interval{op: factor}.Pow(2).op)
+		res = res.Sub(Interval{op: term})
+	}
+	return res
+}
+
+//derive uses chain rule f(u)' = f'(u) * u'
+func (o function) derive(name string) Interval {
+	arg := Interval{op: o.arg}
+	d := derive(o.arg, name)
+	switch o.kind {
+	case funcExp:
+		return Interval{op: o}.Mul(d)
+	case funcLog:
+		return d.Div(arg)
+	case funcSin:
+		return arg.Cos().Mul(d)
+	case funcCos:
+		return integer(-1).Mul(arg.Sin(), d)
+	case funcTan:
+		return integer(1).Add(Interval{op: o}.Pow(2)).Mul(d)
+	case funcAtan:
+		return d.Div(integer(1).Add(arg.Pow(2)))
+	case funcAbs:
+		return arg.Sign().Mul(d)
+	default:
+		return integer(0)
+	}
+}
+
+//derive splits extremum of several operands into extremum of first operand and extremum of the rest
+func (o extremum) derive(name string) Interval {
+	a, b := Interval{op: o.operands[0]}, Interval{op: o.operands[1]}
+	if len(o.operands) > 2 {
+		b.op = extremum{max: o.max, operands: o.operands[1:]}
+	}
+	da, db := derive(a.op, name), derive(b.op, name)
+	diff := a.Sub(b).Sign().Mul(da.Sub(db))
+	if o.max {
+		return da.Add(db, diff).Div(integer(2))
+	}
+	return da.Add(db).Sub(diff).Div(integer(2))
+}
+
+func integer(n int64) Interval {
+	return NewInterval(NewInt(n), NewInt(n))
+}
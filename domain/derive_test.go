@@ -0,0 +1,68 @@
+package domain
+
+import "testing"
+
+func TestIntervalDerive(t *testing.T) {
+	var testPairs = []struct {
+		expr string
+		res  string
+	}{
+		{expr: "[1, 2]", res: "[0, 0]"},
+		{expr: "y", res: "[0, 0]"},
+		{expr: "3 * x + y", res: "[3, 3]"},
+		{expr: "x * x", res: "[2, 2] * x"},
+		{expr: "x^3", res: "[3, 3] * x^2"},
+		{expr: "x^2 * y - y", res: "[2, 2] * x * y"},
+		{expr: "x / y", res: "[1, 1] / y"},
+		{expr: "1 / x", res: "[0, 0] - [1, 1] / x^2"},
+		{expr: "x / x", res: "[0, 0]"},
+		{expr: "sqrt(x)", res: "[1 / 2, 1 / 2] / sqrt(x)"},
+		{expr: "root(x, 3)", res: "[1 / 3, 1 / 3] / root(x, 3)^2"},
+		{expr: "exp(2 * x)", res: "[2, 2] * exp([2, 2] * x)"},
+		{expr: "log(x)", res: "[1, 1] / x"},
+		{expr: "sin(x)", res: "cos(x)"},
+		{expr: "cos(x * x)", res: "[-2, -2] * sin(x^2) * x"},
+		{expr: "tan(x)", res: "[1, 1] + tan(x)^2"},
+		{expr: "atan(x)", res: "[1, 1] / ([1, 1] + x^2)"},
+		{expr: "abs(x)", res: "sign(x)"},
+		{expr: "sign(x)", res: "[0, 0]"},
+		{expr: "max(x, y)", res: "[1 / 2, 1 / 2] * ([1, 1] + sign(x - y))"},
+		{expr: "min(x, y)", res: "[1 / 2, 1 / 2] * ([1, 1] - sign(x - y))"},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := expr.Derive("x").String(); res != pair.res {
+			t.Errorf("In pair %d: derivative of %s should be %s, got %s", i, pair.expr, pair.res, res)
+		}
+	}
+}
+
+func TestIntervalDeriveSolve(t *testing.T) {
+	var testPairs = []struct {
+		expr  string
+		value Interval
+		res   string
+	}{
+		{expr: "(x + 1) / (x - 1)", value: NewInterval(NewInt(3), NewInt(3)), res: "[-1 / 2, -1 / 2]"},
+		{expr: "x^3 - 3 * x", value: NewInterval(NewInt(2), NewInt(3)), res: "[9, 24]"},
+		{expr: "max(x, 2 * x, 1)", value: NewInterval(NewInt(2), NewInt(3)), res: "[2, 2]"},
+		{expr: "min(x, 2 * x, 1)", value: NewInterval(NewInt(2), NewInt(3)), res: "[0, 0]"},
+		{expr: "clamp(x, 0, 1)", value: NewInterval(NewFrac(1, 4), NewFrac(1, 2)), res: "[1, 1]"},
+		{expr: "clamp(x, 0, 1)", value: NewInterval(NewInt(2), NewInt(3)), res: "[0, 0]"},
+		{expr: "abs(x - 1)", value: NewInterval(NewInt(-2), NewInt(0)), res: "[-1, -1]"},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := expr.Derive("x").Solve(VarMap{"x": pair.value}).String(); res != pair.res {
+			t.Errorf("In pair %d: derivative of %s on %s should be %s, got %s", i, pair.expr, pair.value, pair.res, res)
+		}
+	}
+}
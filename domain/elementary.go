@@ -0,0 +1,291 @@
+package domain
+
+import "math/big"
+
+//workingBits is number of extra bits of precision of intermediate results of elementary functions
+const workingBits = 10
+
+//expLimit is the greatest argument of exp which is computed, greater ones are bounded with e^expLimit and Inf
+var expLimit = NewInt(10000)
+
+//applyConst returns interval containing values of function of kind at every point of i.
+//Bounds of elementary functions are rounded outward to multiple of 1 / roundingDenominator,
+//piecewise functions are computed exactly
+func (i constInterval) applyConst(kind funcKind) constInterval {
+	if i.absorbing() {
+		return i
+	}
+	switch kind {
+	case funcExp:
+		return monotonic(i, expPoint)
+	case funcLog:
+		if i.right.sign() <= 0 {
+			return emptyInterval()
+		}
+		return monotonic(i, logPoint)
+	case funcSin:
+		return i.sinShifted(0)
+	case funcCos:
+		return i.sinShifted(1)
+	case funcTan:
+		return i.tan()
+	case funcAtan:
+		return monotonic(i, atanPoint)
+	case funcAbs:
+		return i.absConst()
+	case funcSign:
+		return i.signConst()
+	}
+	panic("unknown function " + kind.String())
+}
+
+//monotonic returns enclosure of increasing function on i using enclosures of its values at bounds
+func monotonic(i constInterval, f func(x *Value) constInterval) constInterval {
+	return constInterval{f(i.left).left, f(i.right).right}.roundOut()
+}
+
+//roundOut rounds left bound down and right bound up to multiple of 1 / roundingDenominator
+func (i constInterval) roundOut() constInterval {
+	d := big.NewInt(roundingDenominator)
+	return constInterval{roundBound(i.left, d, RoundDown), roundBound(i.right, d, RoundUp)}
+}
+
+//roundBound rounds v in direction mode to multiple of 1 / denom. Infinite values are not changed
+func roundBound(v *Value, denom *big.Int, mode RoundingMode) *Value {
+	if v.IsInf() || v.IsNaN() {
+		return new(Value).set(v)
+	}
+	num := divRound(new(big.Int).Mul(v.num, denom), v.denom, mode)
+	return (&Value{num: num, denom: new(big.Int).Set(denom)}).reduce()
+}
+
+//tolerance returns precision of intermediate results, which is finer than 1 / roundingDenominator
+func tolerance() *Value {
+	return &Value{num: big.NewInt(1), denom: new(big.Int).Lsh(big.NewInt(roundingDenominator), workingBits)}
+}
+
+//toleranceFor returns tolerance divided by ceil(|x|) + 1, which is used if error is multiplied by x
+func toleranceFor(x *Value) *Value {
+	abs := new(Value).Abs(x)
+	k := divRound(abs.num, abs.denom, RoundUp)
+	return new(Value).div(tolerance(), &Value{num: k.Add(k, big.NewInt(1)), denom: big.NewInt(1)})
+}
+
+//shift returns v * 2^k
+func shift(v *Value, k int) *Value {
+	res := new(Value).set(v)
+	if k >= 0 {
+		res.num.Lsh(res.num, uint(k))
+	} else {
+		res.denom.Lsh(res.denom, uint(-k))
+	}
+	return res.reduce()
+}
+
+func point(v *Value) constInterval {
+	return constInterval{v, v}
+}
+
+func negConst(i constInterval) constInterval {
+	return add{}.neutral().subConst(i)
+}
+
+//expPoint returns enclosure of e^x
+func expPoint(x *Value) constInterval {
+	switch {
+	case x.IsInf() && x.sign() > 0:
+		return point(Inf())
+	case x.IsInf():
+		return point(Zero())
+	case x.cmp(expLimit) > 0:
+		return constInterval{expPoint(expLimit).left, Inf()}
+	case x.cmp(new(Value).Neg(expLimit)) < 0:
+		return constInterval{Zero(), expPoint(new(Value).Neg(expLimit)).right}
+	case x.sign() < 0:
+		pos := expPoint(new(Value).Neg(x))
+		return constInterval{new(Value).div(One(), pos.right), new(Value).div(One(), pos.left)}
+	}
+	//e^x = (e^(x / 2^s))^(2^s), where x / 2^s <= 1/2
+	s := 0
+	for shift(x, -s).cmp(NewFrac(1, 2)) > 0 {
+		s++
+	}
+	res := expSeries(shift(x, -s), shift(tolerance(), -s))
+	denom := new(big.Int).Lsh(big.NewInt(roundingDenominator), uint(workingBits+s))
+	for ; s > 0; s-- {
+		res = constInterval{
+			left:  roundBound(new(Value).mul(res.left, res.left), denom, RoundDown),
+			right: roundBound(new(Value).mul(res.right, res.right), denom, RoundUp),
+		}
+	}
+	return res
+}
+
+//expSeries returns enclosure of e^r for |r| <= 1/2 computed with Taylor series.
+//Remainder after term r^n / n! is less than this term
+func expSeries(r *Value, eps *Value) constInterval {
+	sum, term := One(), One()
+	for i := int64(1); ; i++ {
+		term = new(Value).div(new(Value).mul(term, r), NewInt(i))
+		sum = new(Value).add(sum, term)
+		if new(Value).Abs(term).cmp(eps) < 0 {
+			break
+		}
+	}
+	return widen(sum, new(Value).Abs(term))
+}
+
+//widen returns [v - r, v + r]
+func widen(v *Value, r *Value) constInterval {
+	return constInterval{new(Value).sub(v, r), new(Value).add(v, r)}
+}
+
+//logPoint returns enclosure of natural logarithm of x, which is -Inf for non-positive x
+func logPoint(x *Value) constInterval {
+	if x.sign() <= 0 {
+		return point(NegInf())
+	}
+	if x.IsInf() {
+		return point(Inf())
+	}
+	//log(x) = k * log(2) + log(m), where m = x / 2^k lies in [1/2, 1]
+	k := x.num.BitLen() - x.denom.BitLen()
+	for shift(x, -k).cmp(One()) > 0 {
+		k++
+	}
+	for shift(x, -k).cmp(NewFrac(1, 2)) < 0 {
+		k--
+	}
+	m := shift(x, -k)
+	eps := toleranceFor(NewInt(int64(k)))
+	two := point(NewInt(2))
+	//log(m) = 2 * atanh((m - 1) / (m + 1)) and log(2) = 2 * atanh(1/3)
+	res := arcSeries(new(Value).div(new(Value).sub(m, One()), new(Value).add(m, One())), eps, false).mulConst(two)
+	ln2 := arcSeries(NewFrac(1, 3), eps, false).mulConst(two)
+	return res.addConst(ln2.mulConst(point(NewInt(int64(k)))))
+}
+
+//arcSeries returns enclosure of atanh(z) or atan(z) if alternating is true for |z| <= 1/2,
+//computed with series z + (±)z^3 / 3 + z^5 / 5 + ...
+//Remainder after term is less than this term
+func arcSeries(z *Value, eps *Value, alternating bool) constInterval {
+	square := new(Value).mul(z, z)
+	if alternating {
+		square.Neg(square)
+	}
+	sum, power, term := new(Value).set(z), new(Value).set(z), new(Value).set(z)
+	for i := int64(1); new(Value).Abs(term).cmp(eps) >= 0; i++ {
+		power = new(Value).mul(power, square)
+		term = new(Value).div(power, NewInt(2*i+1))
+		sum = new(Value).add(sum, term)
+	}
+	return widen(sum, new(Value).Abs(term))
+}
+
+//piEnclosure returns enclosure of pi computed with Machin's formula pi = 16 * atan(1/5) - 4 * atan(1/239)
+func piEnclosure(eps *Value) constInterval {
+	eps = new(Value).div(eps, NewInt(32))
+	a := arcSeries(NewFrac(1, 5), eps, true).mulConst(point(NewInt(16)))
+	b := arcSeries(NewFrac(1, 239), eps, true).mulConst(point(NewInt(4)))
+	return a.subConst(b)
+}
+
+//atanPoint returns enclosure of arctangent of x
+func atanPoint(x *Value) constInterval {
+	if x.sign() < 0 {
+		return negConst(atanPoint(new(Value).Neg(x)))
+	}
+	eps := tolerance()
+	switch {
+	case x.IsInf():
+		return piEnclosure(eps).mulConst(point(NewFrac(1, 2)))
+	case x.cmp(One()) > 0:
+		//atan(x) = pi / 2 - atan(1 / x)
+		return atanPoint(Inf()).subConst(atanPoint(new(Value).div(One(), x)))
+	case x.cmp(NewFrac(1, 2)) > 0:
+		//atan(x) = pi / 4 + atan((x - 1) / (x + 1))
+		y := new(Value).div(new(Value).sub(x, One()), new(Value).add(x, One()))
+		return piEnclosure(eps).mulConst(point(NewFrac(1, 4))).addConst(arcSeries(y, eps, true))
+	}
+	return arcSeries(x, eps, true)
+}
+
+//sinPoint returns enclosure of sin(x + quarter * pi / 2) for finite x
+func sinPoint(x *Value, quarter int) constInterval {
+	//x = k * pi / 2 + r, where |r| is about pi / 4, sine of x is ±sin(r) or ±cos(r) depending on k
+	halfPi := piEnclosure(toleranceFor(x)).mulConst(point(NewFrac(1, 2)))
+	approx := new(Value).div(x, halfPi.mid())
+	k := divRound(approx.num, approx.denom, RoundNearest)
+	r := point(x).subConst(halfPi.mulConst(point(&Value{num: new(big.Int).Set(k), denom: big.NewInt(1)})))
+	//|sin'| <= 1 and |cos'| <= 1, so value at any point of r differs from value at r.left at most on width of r
+	var res constInterval
+	switch new(big.Int).Mod(k.Add(k, big.NewInt(int64(quarter))), big.NewInt(4)).Int64() {
+	case 0:
+		res = trigSeries(r.left, 1)
+	case 1:
+		res = trigSeries(r.left, 0)
+	case 2:
+		res = negConst(trigSeries(r.left, 1))
+	default:
+		res = negConst(trigSeries(r.left, 0))
+	}
+	res = res.addConst(constInterval{new(Value).Neg(r.width()), r.width()})
+	return res.intersect(constInterval{NewInt(-1), One()})
+}
+
+//trigSeries returns enclosure of sin(r) if first is 1 or cos(r) if first is 0 for |r| <= 1,
+//computed with Taylor series. Series is alternating, so remainder after term is less than this term
+func trigSeries(r *Value, first int64) constInterval {
+	term := One()
+	if first == 1 {
+		term = new(Value).set(r)
+	}
+	square := new(Value).Neg(new(Value).mul(r, r))
+	sum := new(Value).set(term)
+	eps := tolerance()
+	for i := first; new(Value).Abs(term).cmp(eps) >= 0; i += 2 {
+		term = new(Value).div(new(Value).mul(term, square), NewInt((i+1)*(i+2)))
+		sum = new(Value).add(sum, term)
+	}
+	return widen(sum, new(Value).Abs(term))
+}
+
+//sinShifted returns enclosure of sin(x + quarter * pi / 2) for x in i.
+//Result is hull of values at bounds and extrema, which may lie in i
+func (i constInterval) sinShifted(quarter int) constInterval {
+	if !i.isBounded() {
+		return constInterval{NewInt(-1), One()}
+	}
+	res := sinPoint(i.left, quarter).hull(sinPoint(i.right, quarter))
+	if mayContain(i, int64(1-quarter), 4) {
+		res.right = One()
+	}
+	if mayContain(i, int64(3-quarter), 4) {
+		res.left = NewInt(-1)
+	}
+	return res.roundOut()
+}
+
+//tan returns enclosure of tangent of x in i.
+//Tangent increases between poles, so it is enclosed with values at bounds if i contains no pole
+func (i constInterval) tan() constInterval {
+	if !i.isBounded() || mayContain(i, 1, 2) {
+		return constInterval{NegInf(), Inf()}
+	}
+	tan := func(x *Value) constInterval {
+		return sinPoint(x, 0).divConst(sinPoint(x, 1))
+	}
+	return monotonic(i, tan)
+}
+
+//mayContain returns false if bounded i surely does not contain points (offset + period * k) * pi / 2
+//for any integer k, else it returns true
+func mayContain(i constInterval, offset, period int64) bool {
+	halfPi := piEnclosure(toleranceFor(i.mag())).mulConst(point(NewFrac(1, 2)))
+	t := i.divConst(halfPi)
+	//k is the least integer such that offset + period * k >= t.left
+	c := new(Value).div(new(Value).sub(t.left, NewInt(offset)), NewInt(period))
+	k := divRound(c.num, c.denom, RoundUp)
+	candidate := new(Value).add(NewInt(offset), &Value{num: k.Mul(k, big.NewInt(period)), denom: big.NewInt(1)})
+	return candidate.cmp(t.right) <= 0
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestIntervalElementary(t *testing.T) {
+	var testPairs = []struct {
+		interval    Interval
+		left, right float64
+	}{
+		{NewInterval(Zero(), One()).Exp(), 1, math.E},
+		{NewInterval(NegInf(), Zero()).Exp(), 0, 1},
+		{NewInterval(NewInt(-3), NewInt(5)).Exp(), math.Exp(-3), math.Exp(5)},
+		{NewInterval(One(), NewInt(100)).Log(), 0, math.Log(100)},
+		{NewInterval(NewInt(-1), NewInt(1)).Log(), math.Inf(-1), 0},
+		{NewInterval(NewFrac(1, 1000), Inf()).Log(), math.Log(0.001), math.Inf(1)},
+		{NewInterval(Zero(), NewInt(4)).Sin(), math.Sin(4), 1},
+		{NewInterval(NewInt(-10), NewInt(10)).Sin(), -1, 1},
+		{NewInterval(NewInt(4), NewInt(5)).Sin(), -1, math.Sin(4)},
+		{NewInterval(NewFrac(1, 2), NewFrac(3, 2)).Sin(), math.Sin(0.5), math.Sin(1.5)},
+		{NewInterval(NewInt(-1), NewInt(1)).Cos(), math.Cos(1), 1},
+		{NewInterval(NewInt(3), NewInt(4)).Cos(), -1, math.Cos(4)},
+		{NewInterval(NegInf(), Zero()).Cos(), -1, 1},
+		{NewInterval(NewInt(-1), NewInt(1)).Tan(), math.Tan(-1), math.Tan(1)},
+		{NewInterval(NewInt(2), NewInt(4)).Tan(), math.Tan(2), math.Tan(4)},
+		{NewInterval(One(), NewInt(2)).Tan(), math.Inf(-1), math.Inf(1)},
+		{NewInterval(NegInf(), Inf()).Atan(), -math.Pi / 2, math.Pi / 2},
+		{NewInterval(NewInt(-3), NewFrac(3, 4)).Atan(), math.Atan(-3), math.Atan(0.75)},
+		{NewInterval(NewInt(100000), NewInt(100001)).Sin(), math.Sin(100001), math.Sin(100000)},
+	}
+	for i, pair := range testPairs {
+		left, right, ok := pair.interval.Solve(VarMap{}).Float64Bounds()
+		if !ok {
+			t.Errorf("In pair %d: %s should be solved to constant", i, pair.interval)
+			continue
+		}
+		if !near(left, pair.left) || !near(right, pair.right) {
+			t.Errorf("In pair %d: %s should be solved to about [%v, %v], got [%v, %v]", i, pair.interval, pair.left, pair.right, left, right)
+		}
+	}
+
+	var specialPairs = []struct {
+		interval Interval
+		res      string
+	}{
+		{NewInterval(NewInt(-2), NewInt(-1)).Log(), "[]"},
+		{NewInterval(Zero(), Zero()).Exp(), "[1, 1]"},
+		{NewInterval(Zero(), Zero()).Sin(), "[0, 0]"},
+		{NewInterval(NaN(), NaN()).Cos(), "[NaN, NaN]"},
+		{Empty().Atan(), "[]"},
+	}
+	for i, pair := range specialPairs {
+		if res := pair.interval.Solve(VarMap{}).String(); res != pair.res {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, pair.interval, pair.res, res)
+		}
+	}
+
+	left, right, _ := NewInterval(NewInt(20000), NewInt(20000)).Exp().Solve(VarMap{}).Bounds()
+	if !right.IsInf() || left.cmp(NewInt(1)) <= 0 {
+		t.Errorf("exp(20000) should be enclosed with [e^10000, Inf], got [%s, %s]", left, right)
+	}
+}
+
+func TestIntervalElementaryString(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	expr := x.Exp().Add(x.Mul(y).Sin()).Mul(y.Log())
+	if res := expr.String(); res != "(exp(x) + sin(x * y)) * log(y)" {
+		t.Errorf("String should be (exp(x) + sin(x * y)) * log(y), got %s", res)
+	}
+	if res := expr.Solve(VarMap{"y": NewInterval(Zero(), Zero())}).String(); res != "[]" {
+		t.Errorf("Expression should be solved to [], got %s", res)
+	}
+	if res := expr.Solve(VarMap{"y": NewInterval(One(), One())}).String(); res != "[0, 0]" {
+		t.Errorf("Expression should be solved to [0, 0], got %s", res)
+	}
+}
+
+func TestPiEnclosure(t *testing.T) {
+	pi := piEnclosure(tolerance())
+	left, right, _ := Interval{op: pi}.Float64Bounds()
+	if left > math.Pi || right < math.Pi || right-left > 1e-12 {
+		t.Errorf("Pi should be enclosed tightly, got [%v, %v]", left, right)
+	}
+}
+
+func TestIntervalElementaryInclusion(t *testing.T) {
+	functions := []struct {
+		name  string
+		op    func(i Interval) Interval
+		point func(x float64) float64
+	}{
+		{"Exp", Interval.Exp, math.Exp},
+		{"Log", Interval.Log, math.Log},
+		{"Sin", Interval.Sin, math.Sin},
+		{"Cos", Interval.Cos, math.Cos},
+		{"Tan", Interval.Tan, math.Tan},
+		{"Atan", Interval.Atan, math.Atan},
+	}
+	for _, f := range functions {
+		property := func(a inclusionCase) bool {
+			x, _ := a.point.Float64(RoundNearest)
+			y := f.point(x)
+			if math.IsNaN(y) || math.IsInf(y, 0) {
+				return true
+			}
+			left, right, ok := f.op(Interval{op: a.interval}).Solve(VarMap{}).Float64Bounds()
+			return ok && (left <= y || near(left, y)) && (y <= right || near(right, y))
+		}
+		if err := quick.Check(property, &quick.Config{MaxCount: 300, Rand: rand.New(rand.NewSource(1))}); err != nil {
+			t.Errorf("%s violates inclusion property: %s", f.name, err)
+		}
+	}
+}
+
+//near reports whether a and b are equal up to rounding errors of float64
+func near(a, b float64) bool {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//defaultPrecision is used by Format if precision is not passed
+const defaultPrecision = 6
+
+//FloatString returns decimal representation of v with n digits after decimal point.
+//Last digit is rounded to nearest, halves are rounded away from zero.
+//Infinite values and NaN are represented as Inf, -Inf and NaN
+func (v *Value) FloatString(n int) string {
+	if n < 0 {
+		n = 0
+	}
+	return v.decimal('f', n, RoundNearest, false)
+}
+
+//Format implements fmt.Formatter.
+//Verbs %v and %s print exact fraction like String does.
+//Verbs %f, %F, %e, %E, %g and %G print decimal representation rounded to nearest with passed precision,
+//which is 6 by default. Flags '+', ' ', '-', '0', '#' and width are supported as for float64
+func (v Value) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		pad(f, v.String(), false)
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		pad(f, signed(f, v.decimal(verb, precision(f), RoundNearest, f.Flag('#'))), true)
+	default:
+		fmt.Fprintf(f, "%%!%c(domain.Value=%s)", verb, v.String())
+	}
+}
+
+//Format implements fmt.Formatter.
+//Verbs %v and %s print exact expression like String does.
+//Verbs %f, %F, %e, %E, %g and %G print bounds of constant interval in decimal representation
+//with passed precision, which is 6 by default. Left bound is rounded down and right bound is rounded up,
+//so printed interval always contains the exact one. Interval with variables is printed like with %v
+func (i Interval) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		pad(f, i.String(), false)
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		c, ok := i.op.(constInterval)
+		if !ok || c.isEmpty() || c.isUndefined() {
+			pad(f, i.String(), false)
+			return
+		}
+		prec := precision(f)
+		left := signed(f, c.left.decimal(verb, prec, RoundDown, f.Flag('#')))
+		right := signed(f, c.right.decimal(verb, prec, RoundUp, f.Flag('#')))
+		pad(f, "["+left+", "+right+"]", false)
+	default:
+		fmt.Fprintf(f, "%%!%c(domain.Interval=%s)", verb, i.String())
+	}
+}
+
+func precision(f fmt.State) int {
+	if prec, ok := f.Precision(); ok {
+		return prec
+	}
+	return defaultPrecision
+}
+
+//signed adds sign requested with flags '+' or ' ' to non-negative number
+func signed(f fmt.State, s string) string {
+	if strings.HasPrefix(s, "-") || s == "NaN" {
+		return s
+	}
+	if f.Flag('+') {
+		return "+" + s
+	}
+	if f.Flag(' ') {
+		return " " + s
+	}
+	return s
+}
+
+//pad writes s to f justified to width of f. Numbers are padded with zeros after sign if '0' flag is passed
+func pad(f fmt.State, s string, number bool) {
+	width, ok := f.Width()
+	if !ok || len(s) >= width {
+		fmt.Fprint(f, s)
+		return
+	}
+	fill := width - len(s)
+	switch {
+	case f.Flag('-'):
+		s += strings.Repeat(" ", fill)
+	case f.Flag('0') && number && !strings.HasSuffix(s, "Inf") && s != "NaN":
+		sign := ""
+		if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") || strings.HasPrefix(s, " ") {
+			sign, s = s[:1], s[1:]
+		}
+		s = sign + strings.Repeat("0", fill) + s
+	default:
+		s = strings.Repeat(" ", fill) + s
+	}
+	fmt.Fprint(f, s)
+}
+
+//decimal returns decimal representation of v in format of verb f, F, e, E, g or G with precision prec.
+//Last printed digit is rounded in direction mode, sharp keeps trailing zeros for g and G
+func (v *Value) decimal(verb rune, prec int, mode RoundingMode, sharp bool) string {
+	v.checkNil()
+
+	if v.IsNaN() {
+		return "NaN"
+	}
+	if v.IsInf() {
+		if v.sign() < 0 {
+			return "-Inf"
+		}
+		return "Inf"
+	}
+	switch verb {
+	case 'f', 'F':
+		return fixed(v.scaled(prec, mode), prec)
+	case 'e', 'E':
+		digits, exp := v.significant(prec+1, mode)
+		return scientific(digits, exp, prec, byte(verb))
+	}
+	if prec == 0 {
+		prec = 1
+	}
+	digits, exp := v.significant(prec, mode)
+	var res string
+	if exp < -4 || exp >= prec {
+		e := byte('e')
+		if verb == 'G' {
+			e = 'E'
+		}
+		res = scientific(digits, exp, prec-1, e)
+	} else {
+		res = fixed(digits, prec-1-exp)
+	}
+	if sharp {
+		return res
+	}
+	return trimZeros(res)
+}
+
+//scaled returns v * 10^n rounded to integer in direction mode
+func (v *Value) scaled(n int, mode RoundingMode) *big.Int {
+	num := new(big.Int).Set(v.num)
+	denom := new(big.Int).Set(v.denom)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n))), nil)
+	if n >= 0 {
+		num.Mul(num, scale)
+	} else {
+		denom.Mul(denom, scale)
+	}
+	return divRound(num, denom, mode)
+}
+
+//divRound returns a / b rounded in direction mode, b should be positive.
+//Halves are rounded away from zero if mode is RoundNearest
+func divRound(a, b *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).DivMod(a, b, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	switch mode {
+	case RoundUp:
+		return q.Add(q, big.NewInt(1))
+	case RoundNearest:
+		cmp := new(big.Int).Lsh(r, 1).Cmp(b)
+		if cmp > 0 || cmp == 0 && a.Sign() > 0 {
+			return q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+//significant returns v rounded in direction mode to n significant digits as integer
+//with exactly n digits and decimal exponent of its first digit
+func (v *Value) significant(n int, mode RoundingMode) (*big.Int, int) {
+	if v.sign() == 0 {
+		return new(big.Int), 0
+	}
+	exp := len(new(big.Int).Abs(v.num).String()) - len(v.denom.String())
+	abs := new(Value).Abs(v)
+	if abs.cmp(pow10(exp)) < 0 {
+		exp--
+	}
+	digits := v.scaled(n-1-exp, mode)
+	if len(new(big.Int).Abs(digits).String()) > n {
+		exp++
+		digits = v.scaled(n-1-exp, mode)
+	}
+	return digits, exp
+}
+
+func pow10(n int) *Value {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n))), nil)
+	if n >= 0 {
+		return &Value{num: p, denom: big.NewInt(1)}
+	}
+	return &Value{num: big.NewInt(1), denom: p}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+//fixed returns q / 10^prec in fixed point notation
+func fixed(q *big.Int, prec int) string {
+	sign := ""
+	if q.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(q).String()
+	if len(digits) <= prec {
+		digits = strings.Repeat("0", prec-len(digits)+1) + digits
+	}
+	if prec == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-prec] + "." + digits[len(digits)-prec:]
+}
+
+//scientific returns q / 10^prec * 10^exp in scientific notation, q should have prec + 1 digits
+func scientific(q *big.Int, exp int, prec int, e byte) string {
+	mantissa := fixed(q, prec)
+	sign := "+"
+	if exp < 0 {
+		sign = "-"
+	}
+	exponent := strconv.Itoa(abs(exp))
+	if len(exponent) < 2 {
+		exponent = "0" + exponent
+	}
+	return mantissa + string(e) + sign + exponent
+}
+
+//trimZeros removes trailing zeros of fraction part of number and decimal point if fraction part is empty
+func trimZeros(s string) string {
+	mantissa, exponent := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent = s[:i], s[i:]
+	}
+	if strings.Contains(mantissa, ".") {
+		mantissa = strings.TrimRight(mantissa, "0")
+		mantissa = strings.TrimSuffix(mantissa, ".")
+	}
+	return mantissa + exponent
+}
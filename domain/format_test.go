@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValueFormat(t *testing.T) {
+	var testPairs = []struct {
+		format string
+		v      *Value
+		res    string
+	}{
+		{format: "%v", v: NewFrac(199, 63), res: "199 / 63"},
+		{format: "%s", v: NewFrac(-1, 3), res: "-1 / 3"},
+		{format: "%f", v: NewFrac(199, 63), res: "3.158730"},
+		{format: "%.2f", v: NewFrac(-2, 3), res: "-0.67"},
+		{format: "%.0f", v: NewFrac(5, 2), res: "3"},
+		{format: "%.0f", v: NewFrac(-5, 2), res: "-3"},
+		{format: "%.3f", v: NewFrac(1, 8000), res: "0.000"},
+		{format: "%.3F", v: NewInt(12), res: "12.000"},
+		{format: "%e", v: NewFrac(199, 63), res: "3.158730e+00"},
+		{format: "%.2e", v: NewFrac(-1, 8000), res: "-1.25e-04"},
+		{format: "%.2E", v: NewInt(9995), res: "1.00E+04"},
+		{format: "%e", v: Zero(), res: "0.000000e+00"},
+		{format: "%g", v: NewFrac(199, 63), res: "3.15873"},
+		{format: "%.3g", v: NewFrac(1, 8), res: "0.125"},
+		{format: "%.3g", v: NewInt(123456), res: "1.23e+05"},
+		{format: "%.3G", v: NewFrac(1, 100000), res: "1E-05"},
+		{format: "%#.3g", v: NewInt(1), res: "1.00"},
+		{format: "%g", v: NewInt(100), res: "100"},
+		{format: "%+.1f", v: NewFrac(1, 4), res: "+0.3"},
+		{format: "% .1f", v: NewFrac(1, 4), res: " 0.3"},
+		{format: "%8.2f", v: NewFrac(-1, 4), res: "   -0.25"},
+		{format: "%-8.2f|", v: NewFrac(-1, 4), res: "-0.25   |"},
+		{format: "%08.2f", v: NewFrac(-1, 4), res: "-0000.25"},
+		{format: "%f", v: Inf(), res: "Inf"},
+		{format: "%+f", v: Inf(), res: "+Inf"},
+		{format: "%.2e", v: NegInf(), res: "-Inf"},
+		{format: "%g", v: NaN(), res: "NaN"},
+		{format: "%d", v: NewInt(1), res: "%!d(domain.Value=1)"},
+	}
+	for i, pair := range testPairs {
+		if res := fmt.Sprintf(pair.format, pair.v); res != pair.res {
+			t.Errorf("In pair %d: %s of %s should be %q, got %q", i, pair.format, pair.v, pair.res, res)
+		}
+		if res := fmt.Sprintf(pair.format, *pair.v); res != pair.res {
+			t.Errorf("In pair %d: %s of value %s should be %q, got %q", i, pair.format, pair.v, pair.res, res)
+		}
+	}
+}
+
+func TestValueFloatString(t *testing.T) {
+	var testPairs = []struct {
+		v   *Value
+		n   int
+		res string
+	}{
+		{v: NewFrac(2, 3), n: 4, res: "0.6667"},
+		{v: NewFrac(-2, 3), n: 4, res: "-0.6667"},
+		{v: NewFrac(1, 2), n: 0, res: "1"},
+		{v: NewFrac(-1, 2), n: 0, res: "-1"},
+		{v: NewInt(42), n: 2, res: "42.00"},
+		{v: NegInf(), n: 2, res: "-Inf"},
+	}
+	for i, pair := range testPairs {
+		if res := pair.v.FloatString(pair.n); res != pair.res {
+			t.Errorf("In pair %d: %s with %d digits should be %q, got %q", i, pair.v, pair.n, pair.res, res)
+		}
+	}
+}
+
+func TestIntervalFormat(t *testing.T) {
+	x, _ := Var("x")
+	var testPairs = []struct {
+		format   string
+		interval Interval
+		res      string
+	}{
+		{format: "%v", interval: NewInterval(NewFrac(1, 3), NewFrac(2, 3)), res: "[1 / 3, 2 / 3]"},
+		{format: "%.3f", interval: NewInterval(NewFrac(1, 3), NewFrac(2, 3)), res: "[0.333, 0.667]"},
+		{format: "%.3f", interval: NewInterval(NewFrac(-2, 3), NewFrac(-1, 3)), res: "[-0.667, -0.333]"},
+		{format: "%.2f", interval: NewInterval(NewFrac(1, 4), NewFrac(1, 4)), res: "[0.25, 0.25]"},
+		{format: "%.1f", interval: NewInterval(NewFrac(1, 4), NewFrac(1, 4)), res: "[0.2, 0.3]"},
+		{format: "%.2e", interval: NewInterval(NewFrac(-1, 3), NewInt(1000)), res: "[-3.34e-01, 1.00e+03]"},
+		{format: "%.2e", interval: NewInterval(NewFrac(9999, 10000), NewFrac(9999, 10000)), res: "[9.99e-01, 1.00e+00]"},
+		{format: "%.3g", interval: NewInterval(NewFrac(1, 3), NewFrac(2, 3)), res: "[0.333, 0.667]"},
+		{format: "%.2f", interval: NewInterval(NegInf(), NewFrac(1, 3)), res: "[-Inf, 0.34]"},
+		{format: "%.2f", interval: Empty(), res: "[]"},
+		{format: "%.2f", interval: x, res: "x"},
+	}
+	for i, pair := range testPairs {
+		if res := fmt.Sprintf(pair.format, pair.interval); res != pair.res {
+			t.Errorf("In pair %d: %s of %s should be %q, got %q", i, pair.format, pair.interval, pair.res, res)
+		}
+	}
+}
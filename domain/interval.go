@@ -5,6 +5,10 @@ Package domain describes intervals and base operation on them:
 	<li> Sub </li>
 	<li> Mul </li>
 	<li> Div </li>
+	<li> Pow </li>
+	<li> Sqrt, Root, SqrtPrec, RootPrec </li>
+	<li> Exp, Log, Sin, Cos, Tan, Atan </li>
+	<li> Abs, Sign, Min, Max, Clamp </li>
 </ul>
 */
 package domain
@@ -16,32 +20,185 @@ import (
 
 var varRegexp = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9]*$")
 
+//validVarName returns true if name matches varRegexp and is not Inf or NaN, which are parsed as constants
+func validVarName(name string) bool {
+	return varRegexp.MatchString(name) && name != "Inf" && name != "NaN"
+}
+
+//ErrNaNBound is returned by NewIntervalChecked if one of bounds is NaN
+var ErrNaNBound = errors.New("interval bound is NaN")
+
+//ErrReversedBounds is returned by NewIntervalChecked if left bound is greater than right bound
+var ErrReversedBounds = errors.New("left bound of interval is greater than right bound")
+
+//ErrInfiniteBound is returned by NewIntervalChecked if interval starts at Inf or ends at -Inf
+var ErrInfiniteBound = errors.New("interval can not start at Inf or end at -Inf")
+
 //Interval structure describing interval with operations on it
 type Interval struct {
 	op operation
 }
 
-//NewInterval creates new interval with const values of left and right bounds of interval
+//NewInterval creates new interval with const values of left and right bounds of interval.
+//Bounds are normalised: if left > right they are swapped, if one of them is NaN interval is undefined.
+//Use NewIntervalChecked to reject such bounds instead
 func NewInterval(left *Value, right *Value) Interval {
+	if left.IsNaN() || right.IsNaN() {
+		return Interval{op: undefinedInterval()}
+	}
+	if left.cmp(right) > 0 {
+		left, right = right, left
+	}
 	return Interval{
 		op: constInterval{
-			left:  left,
-			right: right,
+			left:  new(Value).set(left),
+			right: new(Value).set(right),
 		},
 	}
 }
 
+//NewIntervalChecked creates new interval with const values of left and right bounds of interval.
+//Returns error if one of bounds is NaN, left > right, left is Inf or right is -Inf
+func NewIntervalChecked(left *Value, right *Value) (Interval, error) {
+	if left.IsNaN() || right.IsNaN() {
+		return Interval{}, ErrNaNBound
+	}
+	if left.cmp(right) > 0 {
+		return Interval{}, ErrReversedBounds
+	}
+	if left.cmp(Inf()) == 0 || right.cmp(NegInf()) == 0 {
+		return Interval{}, ErrInfiniteBound
+	}
+	return NewInterval(left, right), nil
+}
+
+//Empty returns interval which contains no points.
+//Every operation with empty interval results in empty interval
+func Empty() Interval {
+	return Interval{op: emptyInterval()}
+}
+
+//IsEmpty returns true if interval is constant and contains no points
+func (i Interval) IsEmpty() bool {
+	c, ok := i.op.(constInterval)
+	return ok && c.isEmpty()
+}
+
 //String returns string representation of interval
 func (i Interval) String() string {
 	return i.op.String()
 }
 
+//IsUndefined returns true if interval is constant and one of its bounds is NaN.
+//Such interval is a result of operations without meaning and it is propagated by all operations
+func (i Interval) IsUndefined() bool {
+	c, ok := i.op.(constInterval)
+	return ok && c.isUndefined()
+}
+
 //Solve folds and solve interval with variable values passed in VarMap. Returns folded and solved interval
 func (i Interval) Solve(varMap VarMap) Interval {
 	i.op = i.op.Solve(varMap)
 	return i
 }
 
+//IsConst returns true if interval is folded into constant bounds and contains no variables
+func (i Interval) IsConst() bool {
+	_, ok := i.op.(constInterval)
+	return ok
+}
+
+//Bounds returns copies of left and right bounds of constant interval.
+//ok is false if interval is empty or still contains variables
+func (i Interval) Bounds() (left, right *Value, ok bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return nil, nil, false
+	}
+	return new(Value).set(c.left), new(Value).set(c.right), true
+}
+
+//IsPoint returns true if interval is constant and its bounds are equal finite values
+func (i Interval) IsPoint() bool {
+	c, ok := i.nonEmpty()
+	return ok && c.isBounded() && c.left.cmp(c.right) == 0
+}
+
+//IsBounded returns true if interval is constant, not empty and both of its bounds are finite
+func (i Interval) IsBounded() bool {
+	c, ok := i.nonEmpty()
+	return ok && c.isBounded()
+}
+
+//nonEmpty returns folded interval if it is constant and not empty
+func (i Interval) nonEmpty() (constInterval, bool) {
+	c, ok := i.op.(constInterval)
+	return c, ok && !c.isEmpty()
+}
+
+//Width returns right - left of constant interval.
+//Width of unbounded interval is Inf, ok is false if interval is empty or still contains variables
+func (i Interval) Width() (*Value, bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return nil, false
+	}
+	return c.width(), true
+}
+
+//Radius returns half of width of constant interval.
+//ok is false if interval is empty or still contains variables
+func (i Interval) Radius() (*Value, bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return nil, false
+	}
+	return new(Value).div(c.width(), NewInt(2)), true
+}
+
+//Midpoint returns (left + right) / 2 of constant interval.
+//Midpoint of [-Inf, Inf] is 0, midpoint of half-line is its infinite bound.
+//ok is false if interval is empty or still contains variables
+func (i Interval) Midpoint() (*Value, bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return nil, false
+	}
+	return c.mid(), true
+}
+
+//Magnitude returns max(|left|, |right|) of constant interval.
+//ok is false if interval is empty or still contains variables
+func (i Interval) Magnitude() (*Value, bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return nil, false
+	}
+	return c.mag(), true
+}
+
+//Mignitude returns min(|x|) for x in constant interval, it is 0 if interval contains 0.
+//ok is false if interval is empty or still contains variables
+func (i Interval) Mignitude() (*Value, bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return nil, false
+	}
+	return c.mig(), true
+}
+
+//Float64Bounds returns bounds of constant interval converted to float64 with outward rounding,
+//so [left, right] always contains the exact interval. ok is false if interval is empty or still contains variables
+func (i Interval) Float64Bounds() (left, right float64, ok bool) {
+	c, ok := i.nonEmpty()
+	if !ok {
+		return 0, 0, false
+	}
+	left, _ = c.left.Float64(RoundDown)
+	right, _ = c.right.Float64(RoundUp)
+	return left, right, true
+}
+
 type constInterval struct {
 	left  *Value
 	right *Value
@@ -52,6 +209,9 @@ func (i constInterval) Solve(varMap VarMap) operation {
 }
 
 func (i constInterval) String() string {
+	if i.isEmpty() {
+		return "[]"
+	}
 	return "[" + i.left.String() + ", " + i.right.String() + "]"
 }
 
@@ -59,6 +219,14 @@ func (i constInterval) priority() byte {
 	return 255
 }
 
+func (i constInterval) children() []operation {
+	return nil
+}
+
+func (i constInterval) withChildren(children []operation) operation {
+	return i
+}
+
 func (i constInterval) mul(multiplier operation) operation {
 	return mul{
 		k:        i,
@@ -73,94 +241,236 @@ func (i constInterval) add(addend operation) operation {
 	}
 }
 
+//addConst returns [a.left + b.left, a.right + b.right].
+//Inf() + NegInf() in bound is replaced with infinity directed outward of interval
 func (a constInterval) addConst(b constInterval) constInterval {
-	res := constInterval{
-		left:  new(Value),
-		right: new(Value),
+	if a.isUndefined() || b.isUndefined() {
+		return undefinedInterval()
 	}
-	res.left.add(a.left, b.left)
-	if res.left.cmp(res.right) > 0 {
-		res.right, res.left = res.left, res.right
+	if a.isEmpty() || b.isEmpty() {
+		return emptyInterval()
+	}
+	return constInterval{
+		left:  outwardLeft(new(Value).add(a.left, b.left)),
+		right: outwardRight(new(Value).add(a.right, b.right)),
 	}
-	return res
 }
 
+//subConst returns [a.left - b.right, a.right - b.left].
+//Inf() - Inf() in bound is replaced with infinity directed outward of interval
 func (a constInterval) subConst(b constInterval) constInterval {
-	res := constInterval{
-		left:  new(Value),
-		right: new(Value),
+	if a.isUndefined() || b.isUndefined() {
+		return undefinedInterval()
 	}
-	res.left.sub(a.left, b.left)
-	if res.left.cmp(res.right) > 0 {
-		res.right, res.left = res.left, res.right
+	if a.isEmpty() || b.isEmpty() {
+		return emptyInterval()
+	}
+	return constInterval{
+		left:  outwardLeft(new(Value).sub(a.left, b.right)),
+		right: outwardRight(new(Value).sub(a.right, b.left)),
 	}
-	return res
 }
 
-func (a constInterval) mulConst(b constInterval) constInterval {
-	temp := new(Value)
-	min := new(Value)
-	min.mul(a.left, b.left)
-	max := new(Value)
-	max.mul(a.left, b.left)
+//outwardLeft replaces NaN left bound with NegInf, so result still contains every point
+func outwardLeft(v *Value) *Value {
+	if v.IsNaN() {
+		return NegInf()
+	}
+	return v
+}
+
+//outwardRight replaces NaN right bound with Inf, so result still contains every point
+func outwardRight(v *Value) *Value {
+	if v.IsNaN() {
+		return Inf()
+	}
+	return v
+}
 
-	temp.mul(a.left, b.right)
-	if temp.cmp(min) < 0 {
-		min = temp
+func (a constInterval) mulConst(b constInterval) constInterval {
+	if a.isUndefined() || b.isUndefined() {
+		return undefinedInterval()
 	}
-	if temp.cmp(max) > 0 {
-		max = temp
+	if a.isEmpty() || b.isEmpty() {
+		return emptyInterval()
 	}
+	return hullOf(
+		mulBound(a.left, b.left),
+		mulBound(a.left, b.right),
+		mulBound(a.right, b.left),
+		mulBound(a.right, b.right),
+	)
+}
 
-	temp.mul(a.right, b.left)
-	if temp.cmp(min) < 0 {
-		min = temp
+//divConst returns hull of extended division a / b
+func (a constInterval) divConst(b constInterval) constInterval {
+	pieces := a.divExtended(b)
+	if len(pieces) == 0 {
+		return emptyInterval()
+	}
+	return pieces[0].hull(pieces[len(pieces)-1])
+}
+
+//divExtended returns extended division a / b as sorted disjoint pieces.
+//If b contains zero result may be [-Inf, Inf], half-line or union of two half-lines.
+//Division by [0, 0] results in no pieces which means empty interval
+func (a constInterval) divExtended(b constInterval) []constInterval {
+	if a.isUndefined() || b.isUndefined() {
+		return []constInterval{undefinedInterval()}
+	}
+	if a.isEmpty() || b.isEmpty() || b.isZero() {
+		return nil
+	}
+	if !b.contains(Zero()) {
+		return []constInterval{a.divNonZero(b)}
+	}
+	if a.isZero() {
+		return []constInterval{{Zero(), Zero()}}
+	}
+	if a.contains(Zero()) {
+		return []constInterval{{NegInf(), Inf()}}
 	}
-	if temp.cmp(max) > 0 {
-		max = temp
+	p := a.left
+	if a.right.sign() < 0 {
+		p = a.right
 	}
+	//lower piece is p divided by b bound having other sign than p, upper piece by b bound having same sign
+	lowerBy, upperBy := b.left, b.right
+	if p.sign() < 0 {
+		lowerBy, upperBy = b.right, b.left
+	}
+	var res []constInterval
+	if lowerBy.sign() != 0 {
+		res = append(res, constInterval{NegInf(), outwardRight(new(Value).div(p, lowerBy))})
+	}
+	if upperBy.sign() != 0 {
+		res = append(res, constInterval{outwardLeft(new(Value).div(p, upperBy)), Inf()})
+	}
+	//infinite bounds of b give pieces touching at zero
+	if len(res) == 2 && res[0].right.cmp(res[1].left) >= 0 {
+		return []constInterval{{NegInf(), Inf()}}
+	}
+	return res
+}
 
-	temp.mul(a.right, b.right)
-	if temp.cmp(min) < 0 {
-		min = temp
+//divNonZero divides a on b which does not contain zero
+func (a constInterval) divNonZero(b constInterval) constInterval {
+	quotient := func(left, leftBy, right, rightBy *Value) constInterval {
+		return constInterval{
+			left:  outwardLeft(new(Value).div(left, leftBy)),
+			right: outwardRight(new(Value).div(right, rightBy)),
+		}
+	}
+	if b.left.sign() > 0 {
+		if a.left.sign() >= 0 {
+			return quotient(a.left, b.right, a.right, b.left)
+		}
+		if a.right.sign() <= 0 {
+			return quotient(a.left, b.left, a.right, b.right)
+		}
+		return quotient(a.left, b.left, a.right, b.left)
 	}
-	if temp.cmp(max) > 0 {
-		max = temp
+	if a.left.sign() >= 0 {
+		return quotient(a.right, b.right, a.left, b.left)
 	}
-	return constInterval{min, max}
+	if a.right.sign() <= 0 {
+		return quotient(a.right, b.left, a.left, b.right)
+	}
+	return quotient(a.right, b.right, a.left, b.right)
 }
 
-func (a constInterval) divConst(b constInterval) constInterval {
-	temp := new(Value)
-	min := new(Value)
-	min.div(a.left, b.left)
-	max := new(Value)
-	max.div(a.left, b.left)
+func (a constInterval) equal(b constInterval) bool {
+	return a.left.cmp(b.left) == 0 && a.right.cmp(b.right) == 0
+}
+
+func (i constInterval) isBounded() bool {
+	return !i.isUndefined() && !i.left.IsInf() && !i.right.IsInf()
+}
+
+func (i constInterval) width() *Value {
+	if i.isUndefined() {
+		return NaN()
+	}
+	return outwardRight(new(Value).sub(i.right, i.left))
+}
 
-	temp.div(a.left, b.right)
-	if temp.cmp(min) < 0 {
-		min = temp
+func (i constInterval) mid() *Value {
+	if i.left.IsInf() && i.right.IsInf() && i.left.cmp(i.right) != 0 {
+		return Zero()
+	}
+	if i.left.IsInf() {
+		return new(Value).set(i.left)
 	}
-	if temp.cmp(max) > 0 {
-		max = temp
+	if i.right.IsInf() {
+		return new(Value).set(i.right)
 	}
+	return new(Value).div(new(Value).add(i.left, i.right), NewInt(2))
+}
 
-	temp.div(a.right, b.left)
-	if temp.cmp(min) < 0 {
-		min = temp
+func (i constInterval) mag() *Value {
+	left, right := new(Value).Abs(i.left), new(Value).Abs(i.right)
+	if i.isUndefined() {
+		return NaN()
 	}
-	if temp.cmp(max) > 0 {
-		max = temp
+	if left.cmp(right) > 0 {
+		return left
 	}
+	return right
+}
 
-	temp.div(a.right, b.right)
-	if temp.cmp(min) < 0 {
-		min = temp
+func (i constInterval) mig() *Value {
+	if i.isUndefined() {
+		return NaN()
 	}
-	if temp.cmp(max) > 0 {
-		max = temp
+	if i.left.sign() <= 0 && i.right.sign() >= 0 {
+		return Zero()
 	}
-	return constInterval{min, max}
+	left, right := new(Value).Abs(i.left), new(Value).Abs(i.right)
+	if left.cmp(right) < 0 {
+		return left
+	}
+	return right
+}
+
+//undefinedInterval returns interval with NaN bounds, which is a result of operations without meaning
+func undefinedInterval() constInterval {
+	return constInterval{NaN(), NaN()}
+}
+
+func (i constInterval) isZero() bool {
+	return i.left.sign() == 0 && i.right.sign() == 0 && !i.isUndefined()
+}
+
+//absorbing returns true if interval is a result of any operation with it
+func (i constInterval) absorbing() bool {
+	return i.isUndefined() || i.isEmpty()
+}
+
+func (i constInterval) isUndefined() bool {
+	return i.left.IsNaN() || i.right.IsNaN()
+}
+
+//hullOf returns the smallest interval containing all passed bounds
+func hullOf(bounds ...*Value) constInterval {
+	res := constInterval{bounds[0], bounds[0]}
+	for _, bound := range bounds[1:] {
+		if bound.cmp(res.left) < 0 {
+			res.left = bound
+		}
+		if bound.cmp(res.right) > 0 {
+			res.right = bound
+		}
+	}
+	return res
+}
+
+//mulBound multiplies bounds of intervals.
+//Unlike Value.mul it treats 0 * Inf as 0, because bound Inf is never reached by interval
+func mulBound(a, b *Value) *Value {
+	if a.sign() == 0 || b.sign() == 0 {
+		return Zero()
+	}
+	return new(Value).mul(a, b)
 }
 
 //Add returns result of addition current interval and passed addends
@@ -210,13 +520,30 @@ func (i Interval) Div(divider Interval) Interval {
 	return i
 }
 
+//DivExtended returns pieces of extended division of current constant interval on constant divider.
+//If divider does not contain zero result is single interval, else it may be [-Inf, Inf],
+//half-line or two half-lines [-Inf, a] and [b, Inf]. Division by [0, 0] returns no pieces.
+//Returns error if one of intervals still contains variables or is undefined
+func (i Interval) DivExtended(divider Interval) ([]Interval, error) {
+	a, b, err := constants(i, divider)
+	if err != nil {
+		return nil, err
+	}
+	var res []Interval
+	for _, piece := range a.divExtended(b) {
+		res = append(res, Interval{op: piece})
+	}
+	return res, nil
+}
+
 //VarMap type describing variable values in format {"varName", Interval}
 type VarMap map[string]Interval
 
 //Var creates new variable interval with passed name.
-//variable name should contain only letters and digits and start from letter, else creation will return error
+//variable name should contain only letters and digits and start from letter and should not be Inf or NaN,
+//else creation will return error
 func Var(name string) (Interval, error) {
-	if !varRegexp.MatchString(name) {
+	if !validVarName(name) {
 		return Interval{}, errors.New("bad variable name")
 	}
 	return Interval{
@@ -245,6 +572,14 @@ func (i variable) priority() byte {
 	return 255
 }
 
+func (i variable) children() []operation {
+	return nil
+}
+
+func (i variable) withChildren(children []operation) operation {
+	return i
+}
+
 func (i variable) mul(multiplier operation) operation {
 	return mul{
 		k:        mul{}.neutral(),
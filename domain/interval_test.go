@@ -0,0 +1,420 @@
+package domain
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestIntervalUndefined(t *testing.T) {
+	var testPairs = []struct {
+		interval  Interval
+		undefined bool
+		res       string
+	}{
+		{
+			interval:  NewInterval(Zero(), One()).Mul(NewInterval(One(), Inf())),
+			undefined: false,
+			res:       "[0, Inf]",
+		},
+		{
+			interval:  NewInterval(NaN(), One()).Mul(NewInterval(One(), One())),
+			undefined: true,
+			res:       "[NaN, NaN]",
+		},
+		{
+			interval:  NewInterval(One(), One()).Div(NewInterval(NaN(), One())),
+			undefined: true,
+			res:       "[NaN, NaN]",
+		},
+		{
+			interval:  NewInterval(NaN(), One()).Add(NewInterval(One(), One())),
+			undefined: true,
+			res:       "[NaN, NaN]",
+		},
+	}
+	for i, pair := range testPairs {
+		solved := pair.interval.Solve(VarMap{})
+		if solved.IsUndefined() != pair.undefined {
+			t.Errorf("In pair %d: undefined of %s should be %t", i, solved, pair.undefined)
+		}
+		if solved.String() != pair.res {
+			t.Errorf("In pair %d: %s should be equal %s", i, solved, pair.res)
+		}
+	}
+}
+
+//inclusionCase is a random constant interval with random finite point inside it
+type inclusionCase struct {
+	interval constInterval
+	point    *Value
+}
+
+func randomValue(r *rand.Rand) *Value {
+	return NewFrac(r.Int63n(201)-100, r.Int63n(20)+1)
+}
+
+//Generate implements quick.Generator.
+//Every fifth bound is infinite and every fifth interval is a point
+func (inclusionCase) Generate(r *rand.Rand, size int) reflect.Value {
+	left, right := randomValue(r), randomValue(r)
+	if left.cmp(right) > 0 {
+		left, right = right, left
+	}
+	if r.Intn(5) == 0 {
+		right = left
+	}
+	c := inclusionCase{point: new(Value).Add(left, new(Value).Mul(new(Value).Sub(right, left), NewFrac(r.Int63n(11), 10)))}
+	if r.Intn(5) == 0 {
+		left = NegInf()
+	}
+	if r.Intn(5) == 0 {
+		right = Inf()
+	}
+	c.interval = constInterval{left, right}
+	return reflect.ValueOf(c)
+}
+
+func (c inclusionCase) contains(v *Value) bool {
+	return c.interval.left.cmp(v) <= 0 && c.interval.right.cmp(v) >= 0
+}
+
+func checkInclusion(t *testing.T, name string, op func(a, b Interval) Interval, pointOp func(z, a, b *Value) *Value, skip func(b inclusionCase) bool) {
+	property := func(a, b inclusionCase) bool {
+		if skip != nil && skip(b) {
+			return true
+		}
+		point := pointOp(new(Value), a.point, b.point)
+		res, ok := op(Interval{op: a.interval}, Interval{op: b.interval}).Solve(VarMap{}).op.(constInterval)
+		if !ok {
+			return false
+		}
+		return inclusionCase{interval: res}.contains(point)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000, Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Errorf("%s violates inclusion property: %s", name, err)
+	}
+}
+
+func TestIntervalAddInclusion(t *testing.T) {
+	checkInclusion(t, "Add", func(a, b Interval) Interval {
+		return a.Add(b)
+	}, (*Value).Add, nil)
+}
+
+func TestIntervalSubInclusion(t *testing.T) {
+	checkInclusion(t, "Sub", Interval.Sub, (*Value).Sub, nil)
+}
+
+func TestIntervalMulInclusion(t *testing.T) {
+	checkInclusion(t, "Mul", func(a, b Interval) Interval {
+		return a.Mul(b)
+	}, (*Value).Mul, nil)
+}
+
+func TestIntervalDivInclusion(t *testing.T) {
+	checkInclusion(t, "Div", Interval.Div, (*Value).Div, func(b inclusionCase) bool {
+		return b.point.Sign() == 0
+	})
+}
+
+func TestIntervalAddSub(t *testing.T) {
+	var testPairs = []struct {
+		interval Interval
+		res      string
+	}{
+		{
+			interval: NewInterval(NewFrac(1, 2), NewFrac(2, 1)).Add(NewInterval(NewFrac(-1, 1), NewFrac(3, 1))),
+			res:      "[-1 / 2, 5]",
+		},
+		{
+			interval: NewInterval(NewFrac(1, 2), NewFrac(2, 1)).Sub(NewInterval(NewFrac(-1, 1), NewFrac(3, 1))),
+			res:      "[-5 / 2, 3]",
+		},
+		{
+			interval: NewInterval(NegInf(), NewFrac(2, 1)).Add(NewInterval(NewFrac(-1, 1), Inf())),
+			res:      "[-Inf, Inf]",
+		},
+		{
+			interval: NewInterval(NegInf(), NewFrac(2, 1)).Sub(NewInterval(NewFrac(-1, 1), Inf())),
+			res:      "[-Inf, 3]",
+		},
+		{
+			interval: NewInterval(Inf(), Inf()).Sub(NewInterval(Inf(), Inf())),
+			res:      "[-Inf, Inf]",
+		},
+	}
+	for i, pair := range testPairs {
+		if solved := pair.interval.Solve(VarMap{}); solved.String() != pair.res {
+			t.Errorf("In pair %d: %s should be equal %s", i, solved, pair.res)
+		}
+	}
+}
+
+func TestIntervalQueries(t *testing.T) {
+	x, _ := Var("x")
+	var testPairs = []struct {
+		interval  Interval
+		isConst   bool
+		isPoint   bool
+		isBounded bool
+		width     string
+		midpoint  string
+		radius    string
+		magnitude string
+		mignitude string
+	}{
+		{
+			interval:  NewInterval(NewFrac(-1, 2), NewFrac(3, 1)),
+			isConst:   true,
+			isBounded: true,
+			width:     "7 / 2",
+			midpoint:  "5 / 4",
+			radius:    "7 / 4",
+			magnitude: "3",
+			mignitude: "0",
+		},
+		{
+			interval:  NewInterval(NewFrac(-3, 1), NewFrac(-1, 2)),
+			isConst:   true,
+			isBounded: true,
+			width:     "5 / 2",
+			midpoint:  "-7 / 4",
+			radius:    "5 / 4",
+			magnitude: "3",
+			mignitude: "1 / 2",
+		},
+		{
+			interval:  NewInterval(NewFrac(2, 3), NewFrac(2, 3)),
+			isConst:   true,
+			isPoint:   true,
+			isBounded: true,
+			width:     "0",
+			midpoint:  "2 / 3",
+			radius:    "0",
+			magnitude: "2 / 3",
+			mignitude: "2 / 3",
+		},
+		{
+			interval:  NewInterval(NegInf(), Inf()),
+			isConst:   true,
+			width:     "Inf",
+			midpoint:  "0",
+			radius:    "Inf",
+			magnitude: "Inf",
+			mignitude: "0",
+		},
+		{
+			interval:  NewInterval(One(), Inf()),
+			isConst:   true,
+			width:     "Inf",
+			midpoint:  "Inf",
+			radius:    "Inf",
+			magnitude: "Inf",
+			mignitude: "1",
+		},
+		{
+			interval: x.Add(NewInterval(One(), One())),
+		},
+	}
+	str := func(v *Value, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return v.String()
+	}
+	for i, pair := range testPairs {
+		if pair.interval.IsConst() != pair.isConst {
+			t.Errorf("In pair %d: IsConst of %s should be %t", i, pair.interval, pair.isConst)
+		}
+		if pair.interval.IsPoint() != pair.isPoint {
+			t.Errorf("In pair %d: IsPoint of %s should be %t", i, pair.interval, pair.isPoint)
+		}
+		if pair.interval.IsBounded() != pair.isBounded {
+			t.Errorf("In pair %d: IsBounded of %s should be %t", i, pair.interval, pair.isBounded)
+		}
+		if s := str(pair.interval.Width()); s != pair.width {
+			t.Errorf("In pair %d: width of %s should be %q, got %q", i, pair.interval, pair.width, s)
+		}
+		if s := str(pair.interval.Midpoint()); s != pair.midpoint {
+			t.Errorf("In pair %d: midpoint of %s should be %q, got %q", i, pair.interval, pair.midpoint, s)
+		}
+		if s := str(pair.interval.Radius()); s != pair.radius {
+			t.Errorf("In pair %d: radius of %s should be %q, got %q", i, pair.interval, pair.radius, s)
+		}
+		if s := str(pair.interval.Magnitude()); s != pair.magnitude {
+			t.Errorf("In pair %d: magnitude of %s should be %q, got %q", i, pair.interval, pair.magnitude, s)
+		}
+		if s := str(pair.interval.Mignitude()); s != pair.mignitude {
+			t.Errorf("In pair %d: mignitude of %s should be %q, got %q", i, pair.interval, pair.mignitude, s)
+		}
+	}
+	left, right, ok := NewInterval(NewFrac(1, 2), NewFrac(3, 4)).Bounds()
+	if !ok || left.Cmp(NewFrac(1, 2)) != 0 || right.Cmp(NewFrac(3, 4)) != 0 {
+		t.Errorf("Bounds of [1 / 2, 3 / 4] should be 1 / 2 and 3 / 4, got %s and %s", left, right)
+	}
+	if _, _, ok := x.Bounds(); ok {
+		t.Errorf("Bounds of %s should not be resolved", x)
+	}
+}
+
+func TestNewIntervalChecked(t *testing.T) {
+	var testPairs = []struct {
+		left  *Value
+		right *Value
+		err   error
+		res   string
+	}{
+		{left: NewFrac(1, 2), right: NewFrac(5, 3), res: "[1 / 2, 5 / 3]"},
+		{left: NewFrac(5, 3), right: NewFrac(1, 2), err: ErrReversedBounds},
+		{left: NaN(), right: NewFrac(1, 2), err: ErrNaNBound},
+		{left: NewFrac(1, 2), right: NaN(), err: ErrNaNBound},
+		{left: Inf(), right: Inf(), err: ErrInfiniteBound},
+		{left: NegInf(), right: NegInf(), err: ErrInfiniteBound},
+		{left: NegInf(), right: Inf(), res: "[-Inf, Inf]"},
+	}
+	for i, pair := range testPairs {
+		res, err := NewIntervalChecked(pair.left, pair.right)
+		if err != pair.err {
+			t.Errorf("In pair %d: error should be %v, got %v", i, pair.err, err)
+		}
+		if err == nil && res.String() != pair.res {
+			t.Errorf("In pair %d: %s should be equal %s", i, res, pair.res)
+		}
+	}
+	if res := NewInterval(NewFrac(5, 3), NewFrac(1, 2)); res.String() != "[1 / 2, 5 / 3]" {
+		t.Errorf("NewInterval should normalise reversed bounds, got %s", res)
+	}
+	if res := NewInterval(NaN(), NewFrac(1, 2)); !res.IsUndefined() {
+		t.Errorf("NewInterval with NaN bound should be undefined, got %s", res)
+	}
+}
+
+func TestIntervalEmpty(t *testing.T) {
+	x, _ := Var("x")
+	one := NewInterval(One(), One())
+	zero := NewInterval(Zero(), Zero())
+	var testPairs = []Interval{
+		Empty(),
+		Empty().Add(one),
+		one.Sub(Empty()),
+		zero.Mul(Empty()),
+		one.Div(Empty()),
+		Empty().Div(zero),
+		x.Add(one).Mul(x),
+		x.Mul(zero),
+	}
+	for i, interval := range testPairs {
+		res := interval.Solve(VarMap{"x": Empty()})
+		if !res.IsEmpty() || res.String() != "[]" {
+			t.Errorf("In pair %d: %s should be empty, got %s", i, interval, res)
+		}
+		if _, _, ok := res.Bounds(); ok {
+			t.Errorf("In pair %d: bounds of empty interval should not be resolved", i)
+		}
+	}
+}
+
+func TestIntervalDivExtended(t *testing.T) {
+	interval := func(a, b, c, d int64) Interval {
+		return NewInterval(NewFrac(a, b), NewFrac(c, d))
+	}
+	var testPairs = []struct {
+		a   Interval
+		b   Interval
+		res []string
+	}{
+		{a: interval(1, 1, 2, 1), b: interval(2, 1, 4, 1), res: []string{"[1 / 4, 1]"}},
+		{a: interval(-1, 1, 2, 1), b: interval(-4, 1, -2, 1), res: []string{"[-1, 1 / 2]"}},
+		{a: interval(1, 1, 2, 1), b: interval(-1, 1, 2, 1), res: []string{"[-Inf, -1]", "[1 / 2, Inf]"}},
+		{a: interval(-2, 1, -1, 1), b: interval(-1, 1, 2, 1), res: []string{"[-Inf, -1 / 2]", "[1, Inf]"}},
+		{a: interval(1, 1, 2, 1), b: interval(0, 1, 2, 1), res: []string{"[1 / 2, Inf]"}},
+		{a: interval(1, 1, 2, 1), b: interval(-2, 1, 0, 1), res: []string{"[-Inf, -1 / 2]"}},
+		{a: interval(-2, 1, -1, 1), b: interval(0, 1, 2, 1), res: []string{"[-Inf, -1 / 2]"}},
+		{a: interval(-2, 1, -1, 1), b: interval(-2, 1, 0, 1), res: []string{"[1 / 2, Inf]"}},
+		{a: interval(-1, 1, 2, 1), b: interval(-1, 1, 2, 1), res: []string{"[-Inf, Inf]"}},
+		{a: interval(0, 1, 0, 1), b: interval(-1, 1, 2, 1), res: []string{"[0, 0]"}},
+		{a: interval(1, 1, 2, 1), b: interval(0, 1, 0, 1), res: nil},
+		{a: NewInterval(One(), Inf()), b: NewInterval(One(), Inf()), res: []string{"[0, Inf]"}},
+		{a: interval(1, 1, 2, 1), b: NewInterval(NegInf(), Inf()), res: []string{"[-Inf, Inf]"}},
+		{a: interval(-2, 1, -1, 1), b: NewInterval(NegInf(), Inf()), res: []string{"[-Inf, Inf]"}},
+		{a: interval(1, 1, 2, 1), b: NewInterval(NegInf(), NewInt(2)), res: []string{"[-Inf, 0]", "[1 / 2, Inf]"}},
+	}
+	for i, pair := range testPairs {
+		res, err := pair.a.DivExtended(pair.b)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if len(res) != len(pair.res) {
+			t.Errorf("In pair %d: %s / %s should have %d pieces, got %v", i, pair.a, pair.b, len(pair.res), res)
+			continue
+		}
+		for j := range res {
+			if res[j].String() != pair.res[j] {
+				t.Errorf("In pair %d: piece %d of %s / %s should be %s, got %s", i, j, pair.a, pair.b, pair.res[j], res[j])
+			}
+		}
+	}
+	if res := interval(1, 1, 2, 1).Div(interval(-1, 1, 2, 1)).Solve(VarMap{}); res.String() != "[-Inf, Inf]" {
+		t.Errorf("[1, 2] / [-1, 2] should be folded to hull [-Inf, Inf], got %s", res)
+	}
+}
+
+func TestIntervalDivSum(t *testing.T) {
+	x, _ := Var("x")
+	res := x.Add(NewInterval(One(), One())).Div(x)
+	if s := res.String(); s != "([1, 1] + x) * ([1, 1] / x)" {
+		t.Errorf("Division of sum should keep divider, got %s", s)
+	}
+	if s := res.Solve(VarMap{"x": NewInterval(NewInt(2), NewInt(2))}).String(); s != "[3 / 2, 3 / 2]" {
+		t.Errorf("(x + [1, 1]) / x should be solved to [3 / 2, 3 / 2] at x = 2, got %s", s)
+	}
+}
+
+func TestIntervalFloat64Bounds(t *testing.T) {
+	left, right, ok := NewInterval(NewFrac(1, 10), NewFrac(1, 3)).Float64Bounds()
+	if !ok || left != math.Nextafter(0.1, 0) || right != math.Nextafter(1.0/3, 1) {
+		t.Errorf("[1 / 10, 1 / 3] should be converted to outward rounded bounds, got [%v, %v]", left, right)
+	}
+	left, right, ok = NewInterval(NegInf(), One()).Float64Bounds()
+	if !ok || !math.IsInf(left, -1) || right != 1 {
+		t.Errorf("[-Inf, 1] should be converted exactly, got [%v, %v]", left, right)
+	}
+	if _, _, ok := Empty().Float64Bounds(); ok {
+		t.Errorf("Bounds of empty interval should not be resolved")
+	}
+}
+
+func TestVar(t *testing.T) {
+	var testPairs = []struct {
+		name string
+		ok   bool
+	}{
+		{name: "x", ok: true},
+		{name: "x1", ok: true},
+		{name: "Infinity", ok: true},
+		{name: "", ok: false},
+		{name: "1x", ok: false},
+		{name: "x_1", ok: false},
+		{name: "Inf", ok: false},
+		{name: "NaN", ok: false},
+	}
+	for i, pair := range testPairs {
+		v, err := Var(pair.name)
+		if (err == nil) != pair.ok {
+			t.Errorf("In pair %d: creation of variable %q should succeed: %v, got error %v", i, pair.name, pair.ok, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		x, _ := Var("x")
+		parsed, err := Parse(v.Add(x).String())
+		if err != nil || len(parsed.Vars()) != len(v.Add(x).Vars()) {
+			t.Errorf("In pair %d: %s should be parsed with variables %v, got %v, %v", i, v.Add(x), v.Add(x).Vars(), parsed.Vars(), err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+//IntervalSet describes union of sorted disjoint constant intervals.
+//Such sets are results of extended division and can be bound to variables with Interval method
+type IntervalSet struct {
+	pieces []constInterval
+}
+
+//NewIntervalSet creates union of passed constant intervals.
+//Pieces are sorted, overlapping and touching ones are merged, empty ones are dropped.
+//Returns error if one of intervals still contains variables or is undefined
+func NewIntervalSet(intervals ...Interval) (IntervalSet, error) {
+	var pieces []constInterval
+	for _, i := range intervals {
+		c, _, err := constants(i, i)
+		if err != nil {
+			return IntervalSet{}, err
+		}
+		pieces = append(pieces, c)
+	}
+	return IntervalSet{pieces: normalize(pieces)}, nil
+}
+
+//Pieces returns sorted disjoint intervals of set
+func (s IntervalSet) Pieces() []Interval {
+	var res []Interval
+	for _, piece := range s.pieces {
+		res = append(res, Interval{op: piece})
+	}
+	return res
+}
+
+//IsEmpty returns true if set contains no points
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.pieces) == 0
+}
+
+//Hull returns the smallest interval containing all pieces of set
+func (s IntervalSet) Hull() Interval {
+	if len(s.pieces) == 0 {
+		return Empty()
+	}
+	return Interval{op: s.pieces[0].hull(s.pieces[len(s.pieces)-1])}
+}
+
+//Interval returns set as interval, which can be used as variable value in VarMap.
+//Set of single piece is returned as constant interval
+func (s IntervalSet) Interval() Interval {
+	return Interval{op: newMultiInterval(s.pieces)}
+}
+
+//Set returns solved constant interval or union of intervals as IntervalSet.
+//ok is false if interval still contains variables
+func (i Interval) Set() (IntervalSet, bool) {
+	switch op := i.op.(type) {
+	case constInterval:
+		return IntervalSet{pieces: normalize([]constInterval{op})}, true
+	case multiInterval:
+		return IntervalSet{pieces: normalize(op)}, true
+	}
+	return IntervalSet{}, false
+}
+
+//String returns string representation of set
+func (s IntervalSet) String() string {
+	return newMultiInterval(s.pieces).String()
+}
+
+//Add returns set of sums of points from s and t
+func (s IntervalSet) Add(t IntervalSet) IntervalSet {
+	return IntervalSet{pieces: lift(s.pieces, t.pieces, constInterval.addConst)}
+}
+
+//Sub returns set of differences of points from s and t
+func (s IntervalSet) Sub(t IntervalSet) IntervalSet {
+	return IntervalSet{pieces: lift(s.pieces, t.pieces, constInterval.subConst)}
+}
+
+//Mul returns set of products of points from s and t
+func (s IntervalSet) Mul(t IntervalSet) IntervalSet {
+	return IntervalSet{pieces: lift(s.pieces, t.pieces, constInterval.mulConst)}
+}
+
+//Div returns set of quotients of points from s and t.
+//Pieces of t containing zero are divided with extended division, so result is not widened to their hull
+func (s IntervalSet) Div(t IntervalSet) IntervalSet {
+	return IntervalSet{pieces: liftPieces(s.pieces, t.pieces, constInterval.divExtended)}
+}
+
+//lift applies operation on constant intervals to every pair of pieces and returns normalized union of results
+func lift(a, b []constInterval, f func(a, b constInterval) constInterval) []constInterval {
+	var pieces []constInterval
+	for _, x := range a {
+		for _, y := range b {
+			pieces = append(pieces, f(x, y))
+		}
+	}
+	return normalize(pieces)
+}
+
+//liftPieces is lift for operation which returns several pieces for pair of constant intervals
+func liftPieces(a, b []constInterval, f func(a, b constInterval) []constInterval) []constInterval {
+	var pieces []constInterval
+	for _, x := range a {
+		for _, y := range b {
+			pieces = append(pieces, f(x, y)...)
+		}
+	}
+	return normalize(pieces)
+}
+
+//normalize sorts pieces, merges overlapping and touching ones and drops empty ones.
+//If one of pieces is undefined result is single undefined interval
+func normalize(pieces []constInterval) []constInterval {
+	var res []constInterval
+	for _, piece := range pieces {
+		if piece.isUndefined() {
+			return []constInterval{undefinedInterval()}
+		}
+		if !piece.isEmpty() {
+			res = append(res, piece)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].left.cmp(res[j].left) < 0
+	})
+	merged := res[:0]
+	for _, piece := range res {
+		last := len(merged) - 1
+		if last >= 0 && piece.left.cmp(merged[last].right) <= 0 {
+			merged[last] = merged[last].hull(piece)
+			continue
+		}
+		merged = append(merged, piece)
+	}
+	return merged
+}
+
+//multiInterval is union of at least two sorted disjoint constant intervals in expression tree
+type multiInterval []constInterval
+
+//newMultiInterval returns normalized union of pieces.
+//Union of single piece is constant interval, union of no pieces is empty interval
+func newMultiInterval(pieces []constInterval) operation {
+	pieces = normalize(pieces)
+	switch len(pieces) {
+	case 0:
+		return emptyInterval()
+	case 1:
+		return pieces[0]
+	}
+	return multiInterval(pieces)
+}
+
+func (m multiInterval) Solve(varMap VarMap) operation {
+	return m
+}
+
+func (m multiInterval) String() string {
+	var pieces []string
+	for _, piece := range m {
+		pieces = append(pieces, piece.String())
+	}
+	return "{" + strings.Join(pieces, ", ") + "}"
+}
+
+func (m multiInterval) priority() byte {
+	return 255
+}
+
+func (m multiInterval) children() []operation {
+	return nil
+}
+
+func (m multiInterval) withChildren(children []operation) operation {
+	return m
+}
+
+func (m multiInterval) mul(multiplier operation) operation {
+	return mul{
+		k:        mul{}.neutral(),
+		operands: []operation{m, multiplier},
+	}
+}
+
+func (m multiInterval) add(addend operation) operation {
+	return add{
+		m:        add{}.neutral(),
+		operands: []operation{m, addend},
+	}
+}
+
+//foldSets combines constant k with sets and inverse sets using operations f and invF.
+//invF returns pieces of result, so division by pieces containing zero is not widened to their hull.
+//Returns folded constant interval or union of intervals
+func foldSets(k constInterval, sets, invSets []multiInterval, f func(a, b constInterval) constInterval,
+	invF func(a, b constInterval) []constInterval) operation {
+	pieces := []constInterval{k}
+	for _, set := range sets {
+		pieces = lift(pieces, set, f)
+	}
+	for _, set := range invSets {
+		pieces = liftPieces(pieces, set, invF)
+	}
+	return newMultiInterval(pieces)
+}
+
+//subPieces returns difference of a and b as single piece for foldSets
+func subPieces(a, b constInterval) []constInterval {
+	return []constInterval{a.subConst(b)}
+}
@@ -0,0 +1,139 @@
+package domain
+
+import "testing"
+
+func TestIntervalSet(t *testing.T) {
+	interval := func(a, b, c, d int64) Interval {
+		return NewInterval(NewFrac(a, b), NewFrac(c, d))
+	}
+	set := func(intervals ...Interval) IntervalSet {
+		s, err := NewIntervalSet(intervals...)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return s
+	}
+	var testPairs = []struct {
+		set  IntervalSet
+		res  string
+		hull string
+	}{
+		{
+			set:  set(interval(3, 1, 4, 1), interval(1, 1, 2, 1)),
+			res:  "{[1, 2], [3, 4]}",
+			hull: "[1, 4]",
+		},
+		{
+			set:  set(interval(1, 1, 3, 1), interval(2, 1, 4, 1), Empty()),
+			res:  "[1, 4]",
+			hull: "[1, 4]",
+		},
+		{
+			set:  set(interval(1, 1, 2, 1), interval(2, 1, 4, 1), interval(6, 1, 7, 1)),
+			res:  "{[1, 4], [6, 7]}",
+			hull: "[1, 7]",
+		},
+		{
+			set:  set(),
+			res:  "[]",
+			hull: "[]",
+		},
+		{
+			set:  set(interval(1, 1, 2, 1)).Div(set(interval(-1, 1, 2, 1))),
+			res:  "{[-Inf, -1], [1 / 2, Inf]}",
+			hull: "[-Inf, Inf]",
+		},
+		{
+			set:  set(interval(1, 1, 2, 1), interval(5, 1, 6, 1)).Add(set(interval(0, 1, 1, 1))),
+			res:  "{[1, 3], [5, 7]}",
+			hull: "[1, 7]",
+		},
+		{
+			set:  set(interval(1, 1, 2, 1), interval(5, 1, 6, 1)).Sub(set(interval(0, 1, 1, 1))),
+			res:  "{[0, 2], [4, 6]}",
+			hull: "[0, 6]",
+		},
+		{
+			set:  set(interval(1, 1, 2, 1), interval(5, 1, 6, 1)).Mul(set(interval(-1, 1, -1, 1), interval(1, 1, 1, 1))),
+			res:  "{[-6, -5], [-2, -1], [1, 2], [5, 6]}",
+			hull: "[-6, 6]",
+		},
+	}
+	for i, pair := range testPairs {
+		if pair.set.String() != pair.res {
+			t.Errorf("In pair %d: set should be %s, got %s", i, pair.res, pair.set)
+		}
+		if hull := pair.set.Hull(); hull.String() != pair.hull {
+			t.Errorf("In pair %d: hull of %s should be %s, got %s", i, pair.set, pair.hull, hull)
+		}
+	}
+	if _, err := NewIntervalSet(interval(1, 1, 2, 1), NewInterval(NaN(), One())); err != ErrUndefined {
+		t.Errorf("NewIntervalSet with undefined interval should fail with %v, got %v", ErrUndefined, err)
+	}
+}
+
+func TestIntervalSetVariable(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	s, _ := NewIntervalSet(
+		NewInterval(NewFrac(1, 1), NewFrac(2, 1)),
+		NewInterval(NewFrac(5, 1), NewFrac(6, 1)),
+	)
+	zeroSet, _ := NewIntervalSet(
+		NewInterval(NewInt(-1), NewInt(1)),
+		NewInterval(NewInt(3), NewInt(4)),
+	)
+	var testPairs = []struct {
+		interval Interval
+		x        Interval
+		res      string
+	}{
+		{
+			interval: x.Add(NewInterval(One(), One())),
+			res:      "{[2, 3], [6, 7]}",
+		},
+		{
+			interval: x.Mul(NewInterval(NewInt(2), NewInt(2))),
+			res:      "{[2, 4], [10, 12]}",
+		},
+		{
+			interval: NewInterval(NewInt(10), NewInt(10)).Sub(x),
+			res:      "{[4, 5], [8, 9]}",
+		},
+		{
+			interval: x.Add(NewInterval(Zero(), NewInt(3))),
+			res:      "[1, 9]",
+		},
+		{
+			interval: x.Add(y),
+			res:      "{[1, 2], [5, 6]} + y",
+		},
+		{
+			interval: NewInterval(One(), One()).Div(x),
+			res:      "{[1 / 6, 1 / 5], [1 / 2, 1]}",
+		},
+		{
+			interval: NewInterval(One(), One()).Div(x),
+			x:        zeroSet.Interval(),
+			res:      "{[-Inf, -1], [1 / 4, 1 / 3], [1, Inf]}",
+		},
+		{
+			interval: NewInterval(NewInt(2), NewInt(2)).Mul(y).Div(x),
+			x:        zeroSet.Interval(),
+			res:      "{[-Inf, -2], [1 / 2, 2 / 3], [2, Inf]} * y",
+		},
+	}
+	for i, pair := range testPairs {
+		if pair.x.op == nil {
+			pair.x = s.Interval()
+		}
+		res := pair.interval.Solve(VarMap{"x": pair.x})
+		if res.String() != pair.res {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, pair.interval, pair.res, res)
+		}
+	}
+	res, ok := x.Mul(NewInterval(NewInt(2), NewInt(2))).Solve(VarMap{"x": s.Interval()}).Set()
+	if !ok || len(res.Pieces()) != 2 {
+		t.Errorf("Solved interval should be resolved to set of 2 pieces, got %s", res)
+	}
+}
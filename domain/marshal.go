@@ -0,0 +1,552 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+//binaryVersion is the first byte of binary representation of Value and Interval
+const binaryVersion byte = 1
+
+//kinds of values in binary representation
+const (
+	kindPositive byte = iota
+	kindNegative
+	kindInf
+	kindNegInf
+	kindNaN
+)
+
+//errBadBinary is returned when binary representation is malformed
+var errBadBinary = errors.New("bad binary representation")
+
+//MarshalText implements encoding.TextMarshaler.
+//Value is represented as exact fraction without spaces like "35/6", or as "Inf", "-Inf" and "NaN"
+func (v Value) MarshalText() ([]byte, error) {
+	v.checkNil()
+
+	if v.IsNaN() || v.IsInf() || v.denom.Cmp(big.NewInt(1)) == 0 {
+		return []byte(v.String()), nil
+	}
+	return []byte(v.num.String() + "/" + v.denom.String()), nil
+}
+
+//MarshalJSON implements json.Marshaler. Value is represented as JSON string with its text representation
+func (v Value) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+//UnmarshalJSON implements json.Unmarshaler.
+//Value is accepted as JSON string in any format accepted by ParseValue or as JSON number
+func (z *Value) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("bad value %s", data)
+		}
+		s = n.String()
+	}
+	return z.UnmarshalText([]byte(s))
+}
+
+//MarshalBinary implements encoding.BinaryMarshaler.
+//Value is represented as version byte, kind byte, length of numerator, numerator and denominator in big-endian
+func (v Value) MarshalBinary() ([]byte, error) {
+	v.checkNil()
+
+	var buf bytes.Buffer
+	buf.WriteByte(binaryVersion)
+	switch {
+	case v.IsNaN():
+		buf.WriteByte(kindNaN)
+		return buf.Bytes(), nil
+	case v.IsInf() && v.sign() > 0:
+		buf.WriteByte(kindInf)
+		return buf.Bytes(), nil
+	case v.IsInf():
+		buf.WriteByte(kindNegInf)
+		return buf.Bytes(), nil
+	case v.sign() < 0:
+		buf.WriteByte(kindNegative)
+	default:
+		buf.WriteByte(kindPositive)
+	}
+	num := v.num.Bytes()
+	writeUvarint(&buf, uint64(len(num)))
+	buf.Write(num)
+	buf.Write(v.denom.Bytes())
+	return buf.Bytes(), nil
+}
+
+//UnmarshalBinary implements encoding.BinaryUnmarshaler for representation returned by MarshalBinary
+func (z *Value) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != binaryVersion {
+		return errBadBinary
+	}
+	switch data[1] {
+	case kindNaN:
+		z.set(NaN())
+		return nil
+	case kindInf:
+		z.set(Inf())
+		return nil
+	case kindNegInf:
+		z.set(NegInf())
+		return nil
+	case kindPositive, kindNegative:
+	default:
+		return errBadBinary
+	}
+	r := bytes.NewReader(data[2:])
+	length, err := binary.ReadUvarint(r)
+	if err != nil || length > uint64(r.Len()) {
+		return errBadBinary
+	}
+	num := make([]byte, length)
+	io.ReadFull(r, num)
+	denom := make([]byte, r.Len())
+	io.ReadFull(r, denom)
+	res := &Value{
+		num:   new(big.Int).SetBytes(num),
+		denom: new(big.Int).SetBytes(denom),
+	}
+	if res.denom.Sign() == 0 {
+		return errBadBinary
+	}
+	if data[1] == kindNegative {
+		res.num.Neg(res.num)
+	}
+	z.set(res.reduce())
+	return nil
+}
+
+//exprNode is structured representation of operation used for JSON and binary marshaling
+type exprNode struct {
+	Op          string     `json:"op"`
+	Left        *Value     `json:"left,omitempty"`
+	Right       *Value     `json:"right,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	N           int        `json:"n,omitempty"`
+	Denom       int64      `json:"denom,omitempty"`
+	Const       *exprNode  `json:"const,omitempty"`
+	Pieces      []exprNode `json:"pieces,omitempty"`
+	Operands    []exprNode `json:"operands,omitempty"`
+	InvOperands []exprNode `json:"invOperands,omitempty"`
+}
+
+//MarshalText implements encoding.TextMarshaler. Interval is represented with String, zero Interval is empty text
+func (i Interval) MarshalText() ([]byte, error) {
+	if i.op == nil {
+		return nil, nil
+	}
+	return []byte(i.String()), nil
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler. Text is parsed with Parse,
+//empty text is unmarshaled to zero Interval like it is marshaled
+func (i *Interval) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = Interval{}
+		return nil
+	}
+	res, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*i = res
+	return nil
+}
+
+//MarshalJSON implements json.Marshaler.
+//Interval is represented as tree of nodes with fields "op" and operation specific fields,
+//so partially solved expression can be stored and solved later:
+//	{"op": "const", "left": "1/2", "right": "5/3"}
+//	{"op": "empty"}
+//	{"op": "var", "name": "x"}
+//	{"op": "set", "pieces": [{"op": "const", ...}, ...]}
+//	{"op": "add", "const": {...}, "operands": [...], "invOperands": [...]}
+//	{"op": "mul", "const": {...}, "operands": [...], "invOperands": [...]}
+//	{"op": "pow", "operands": [base], "n": 2}
+//	{"op": "root", "operands": [arg], "n": 2, "denom": 1000}, denom is omitted for default rounding to 1 / 2^32
+//	{"op": "exp", "operands": [arg]}, and the same for other elementary functions, abs and sign
+//	{"op": "max", "operands": [...]}, and the same for min
+//	{"op": "clamp", "operands": [x, lo, hi]}
+func (i Interval) MarshalJSON() ([]byte, error) {
+	if i.op == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.op.node())
+}
+
+//UnmarshalJSON implements json.Unmarshaler for representation returned by MarshalJSON
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var n exprNode
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	op, err := n.operation()
+	if err != nil {
+		return err
+	}
+	i.op = op
+	return nil
+}
+
+//MarshalBinary implements encoding.BinaryMarshaler.
+//Interval is represented as version byte followed by tree of nodes in the same structure as in MarshalJSON
+func (i Interval) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(binaryVersion)
+	if i.op == nil {
+		return buf.Bytes(), nil
+	}
+	if err := i.op.node().write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//UnmarshalBinary implements encoding.BinaryUnmarshaler for representation returned by MarshalBinary.
+//Nodes nested deeper than 10000 levels are rejected like in UnmarshalJSON
+func (i *Interval) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return errBadBinary
+	}
+	if len(data) == 1 {
+		i.op = nil
+		return nil
+	}
+	r := bytes.NewReader(data[1:])
+	n, err := readNode(r, 1)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return errBadBinary
+	}
+	op, err := n.operation()
+	if err != nil {
+		return err
+	}
+	i.op = op
+	return nil
+}
+
+func nodesOf(ops []operation) []exprNode {
+	var res []exprNode
+	for _, op := range ops {
+		res = append(res, op.node())
+	}
+	return res
+}
+
+func (i constInterval) node() exprNode {
+	if i.isEmpty() {
+		return exprNode{Op: "empty"}
+	}
+	return exprNode{Op: "const", Left: i.left, Right: i.right}
+}
+
+func (i variable) node() exprNode {
+	return exprNode{Op: "var", Name: i.varName}
+}
+
+func (m multiInterval) node() exprNode {
+	n := exprNode{Op: "set"}
+	for _, piece := range m {
+		n.Pieces = append(n.Pieces, piece.node())
+	}
+	return n
+}
+
+func (o add) node() exprNode {
+	k := o.m.node()
+	return exprNode{Op: "add", Const: &k, Operands: nodesOf(o.operands), InvOperands: nodesOf(o.invOperands)}
+}
+
+func (o mul) node() exprNode {
+	k := o.k.node()
+	return exprNode{Op: "mul", Const: &k, Operands: nodesOf(o.operands), InvOperands: nodesOf(o.invOperands)}
+}
+
+func (o pow) node() exprNode {
+	return exprNode{Op: "pow", Operands: []exprNode{o.base.node()}, N: o.n}
+}
+
+func (o root) node() exprNode {
+	n := exprNode{Op: "root", Operands: []exprNode{o.arg.node()}, N: o.n}
+	if o.denom != roundingDenominator {
+		n.Denom = o.denom
+	}
+	return n
+}
+
+func (o function) node() exprNode {
+	return exprNode{Op: o.kind.String(), Operands: []exprNode{o.arg.node()}}
+}
+
+func (o extremum) node() exprNode {
+	if o.max {
+		return exprNode{Op: "max", Operands: nodesOf(o.operands)}
+	}
+	return exprNode{Op: "min", Operands: nodesOf(o.operands)}
+}
+
+func (o clamp) node() exprNode {
+	return exprNode{Op: "clamp", Operands: nodesOf([]operation{o.x, o.lo, o.hi})}
+}
+
+//operation restores operation from node, checking that all fields required by operation are present
+func (n exprNode) operation() (operation, error) {
+	switch n.Op {
+	case "const":
+		if n.Left == nil || n.Right == nil {
+			return nil, errors.New("const node should have left and right bounds")
+		}
+		return NewInterval(n.Left, n.Right).op, nil
+	case "empty":
+		return emptyInterval(), nil
+	case "var":
+		if !validVarName(n.Name) {
+			return nil, errors.New("bad variable name")
+		}
+		return variable{varName: n.Name}, nil
+	case "set":
+		var pieces []constInterval
+		for _, p := range n.Pieces {
+			piece, err := p.constant()
+			if err != nil {
+				return nil, err
+			}
+			pieces = append(pieces, piece)
+		}
+		return newMultiInterval(pieces), nil
+	case "add", "mul":
+		k, err := n.Const.constant()
+		if err != nil {
+			return nil, err
+		}
+		operands, err := operationsOf(n.Operands)
+		if err != nil {
+			return nil, err
+		}
+		invOperands, err := operationsOf(n.InvOperands)
+		if err != nil {
+			return nil, err
+		}
+		if n.Op == "add" {
+			return add{m: k, operands: operands, invOperands: invOperands}, nil
+		}
+		return mul{k: k, operands: operands, invOperands: invOperands}, nil
+	case "pow", "root":
+		if len(n.Operands) != 1 || n.N < 0 || n.N > maxPower || n.Denom < 0 ||
+			n.Op == "root" && (n.N < 2 || n.N > maxRootDegree) {
+			return nil, fmt.Errorf("%s node should have single operand and valid n", n.Op)
+		}
+		arg, err := n.Operands[0].operation()
+		if err != nil {
+			return nil, err
+		}
+		if n.Op == "root" {
+			denom := n.Denom
+			if denom == 0 {
+				denom = roundingDenominator
+			}
+			return root{arg: arg, n: n.N, denom: denom}, nil
+		}
+		return pow{base: arg, n: n.N}, nil
+	case "min", "max", "clamp":
+		operands, err := operationsOf(n.Operands)
+		if err != nil {
+			return nil, err
+		}
+		if n.Op == "clamp" && len(operands) == 3 {
+			return clamp{x: operands[0], lo: operands[1], hi: operands[2]}, nil
+		}
+		if n.Op != "clamp" && len(operands) >= 2 {
+			return extremum{max: n.Op == "max", operands: operands}, nil
+		}
+		return nil, fmt.Errorf("%s node has wrong number of operands", n.Op)
+	}
+	if kind, ok := funcByName(n.Op); ok {
+		if len(n.Operands) != 1 {
+			return nil, fmt.Errorf("%s node should have single operand", n.Op)
+		}
+		arg, err := n.Operands[0].operation()
+		if err != nil {
+			return nil, err
+		}
+		return function{kind: kind, arg: arg}, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q", n.Op)
+}
+
+//constant restores constant interval from node
+func (n *exprNode) constant() (constInterval, error) {
+	if n == nil {
+		return constInterval{}, errors.New("missing constant node")
+	}
+	op, err := n.operation()
+	if err != nil {
+		return constInterval{}, err
+	}
+	c, ok := op.(constInterval)
+	if !ok {
+		return constInterval{}, fmt.Errorf("node %q should be constant", n.Op)
+	}
+	return c, nil
+}
+
+func operationsOf(nodes []exprNode) ([]operation, error) {
+	var res []operation
+	for _, n := range nodes {
+		op, err := n.operation()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, op)
+	}
+	return res, nil
+}
+
+//write writes node in binary form: op, left, right, name, n, const, pieces, operands and invOperands,
+//where values and strings are prefixed with length, lists with number of nodes and optional nodes with flag
+func (n exprNode) write(buf *bytes.Buffer) error {
+	writeString(buf, n.Op)
+	for _, v := range []*Value{n.Left, n.Right} {
+		if v == nil {
+			writeUvarint(buf, 0)
+			continue
+		}
+		data, err := v.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		writeUvarint(buf, uint64(len(data)))
+		buf.Write(data)
+	}
+	writeString(buf, n.Name)
+	writeVarint(buf, int64(n.N))
+	writeVarint(buf, n.Denom)
+	if n.Const == nil {
+		buf.WriteByte(0)
+	} else {
+		buf.WriteByte(1)
+		if err := n.Const.write(buf); err != nil {
+			return err
+		}
+	}
+	for _, list := range [][]exprNode{n.Pieces, n.Operands, n.InvOperands} {
+		writeUvarint(buf, uint64(len(list)))
+		for _, child := range list {
+			if err := child.write(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//readNode reads node at nesting depth, which should not be greater than maxDepth
+func readNode(r *bytes.Reader, depth int) (exprNode, error) {
+	var n exprNode
+	var err error
+	if depth > maxDepth {
+		return n, errBadBinary
+	}
+	if n.Op, err = readString(r); err != nil {
+		return n, err
+	}
+	for _, v := range []**Value{&n.Left, &n.Right} {
+		data, err := readBytes(r)
+		if err != nil {
+			return n, err
+		}
+		if len(data) == 0 {
+			continue
+		}
+		*v = new(Value)
+		if err := (*v).UnmarshalBinary(data); err != nil {
+			return n, err
+		}
+	}
+	if n.Name, err = readString(r); err != nil {
+		return n, err
+	}
+	power, err := binary.ReadVarint(r)
+	if err != nil {
+		return n, errBadBinary
+	}
+	n.N = int(power)
+	if n.Denom, err = binary.ReadVarint(r); err != nil {
+		return n, errBadBinary
+	}
+	flag, err := r.ReadByte()
+	if err != nil {
+		return n, errBadBinary
+	}
+	if flag == 1 {
+		k, err := readNode(r, depth+1)
+		if err != nil {
+			return n, err
+		}
+		n.Const = &k
+	}
+	for _, list := range []*[]exprNode{&n.Pieces, &n.Operands, &n.InvOperands} {
+		count, err := binary.ReadUvarint(r)
+		if err != nil || count > uint64(r.Len()) {
+			return n, errBadBinary
+		}
+		for j := uint64(0); j < count; j++ {
+			child, err := readNode(r, depth+1)
+			if err != nil {
+				return n, err
+			}
+			*list = append(*list, child)
+		}
+	}
+	return n, nil
+}
+
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	buf.Write(tmp[:binary.PutUvarint(tmp[:], x)])
+}
+
+func writeVarint(buf *bytes.Buffer, x int64) {
+	var tmp [binary.MaxVarintLen64]byte
+	buf.Write(tmp[:binary.PutVarint(tmp[:], x)])
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	writeUvarint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil || length > uint64(r.Len()) {
+		return nil, errBadBinary
+	}
+	data := make([]byte, length)
+	io.ReadFull(r, data)
+	return data, nil
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	data, err := readBytes(r)
+	return string(data), err
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValueMarshal(t *testing.T) {
+	var testPairs = []struct {
+		v    *Value
+		text string
+	}{
+		{NewFrac(35, 6), "35/6"},
+		{NewFrac(-1, 2), "-1/2"},
+		{NewInt(7), "7"},
+		{NewInt(0), "0"},
+		{Inf(), "Inf"},
+		{NegInf(), "-Inf"},
+		{NaN(), "NaN"},
+	}
+	for i, pair := range testPairs {
+		text, err := pair.v.MarshalText()
+		if err != nil || string(text) != pair.text {
+			t.Errorf("In pair %d: text of %s should be %q, got %q, %v", i, pair.v, pair.text, text, err)
+		}
+		data, err := json.Marshal(pair.v)
+		if err != nil || string(data) != `"`+pair.text+`"` {
+			t.Errorf("In pair %d: JSON of %s should be %q, got %s, %v", i, pair.v, pair.text, data, err)
+		}
+		res := new(Value)
+		if err := json.Unmarshal(data, res); err != nil || res.cmp(pair.v) != 0 {
+			t.Errorf("In pair %d: JSON %s should be unmarshaled to %s, got %s, %v", i, data, pair.v, res, err)
+		}
+		bin, err := pair.v.MarshalBinary()
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		res = new(Value)
+		if err := res.UnmarshalBinary(bin); err != nil || res.cmp(pair.v) != 0 {
+			t.Errorf("In pair %d: binary %v should be unmarshaled to %s, got %s, %v", i, bin, pair.v, res, err)
+		}
+	}
+
+	var v Value
+	if err := json.Unmarshal([]byte("0.25"), &v); err != nil || v.cmp(NewFrac(1, 4)) != 0 {
+		t.Errorf("JSON number 0.25 should be unmarshaled to 1 / 4, got %s, %v", v, err)
+	}
+	for _, bad := range []string{`"abc"`, `true`, `{}`} {
+		if err := json.Unmarshal([]byte(bad), &v); err == nil {
+			t.Errorf("JSON %s should not be unmarshaled", bad)
+		}
+	}
+	for _, bad := range [][]byte{nil, {0, kindPositive}, {binaryVersion}, {binaryVersion, 9}, {binaryVersion, kindPositive, 5, 1}, {binaryVersion, kindPositive, 1, 1}} {
+		if err := v.UnmarshalBinary(bad); err == nil {
+			t.Errorf("Binary %v should not be unmarshaled", bad)
+		}
+	}
+}
+
+func TestIntervalMarshal(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	set, _ := NewIntervalSet(NewInterval(NewInt(1), NewInt(2)), NewInterval(NewInt(3), NewInt(4)))
+	var testPairs = []Interval{
+		NewInterval(NewFrac(1, 2), NewFrac(5, 3)),
+		NewInterval(NegInf(), NewFrac(35, 6)),
+		NewInterval(NaN(), NaN()),
+		Empty(),
+		x,
+		set.Interval(),
+		x.Add(set.Interval()),
+		x.Add(y).Mul(x).Sub(y.Div(x)),
+		x.Sub(y.Add(x)).Div(y.Mul(x)),
+		x.Add(y).Pow(3).Sub(x.Pow(-2)),
+		x.Sqrt().Add(y.Root(3)),
+		x.SqrtPrec(1000).Mul(y.RootPrec(-3, 7)),
+		x.Exp().Mul(y.Sin()).Sub(x.Log().Atan()),
+		Max(x, y.Abs(), Min(x, y.Sign())).Add(x.Clamp(y, NewInterval(One(), One()))),
+		NewInterval(NewInt(2), NewInt(3)).Mul(x).Div(NewInterval(NewInt(5), NewInt(6)).Add(x)),
+	}
+	for i, interval := range testPairs {
+		data, err := json.Marshal(interval)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		var res Interval
+		if err := json.Unmarshal(data, &res); err != nil || res.String() != interval.String() {
+			t.Errorf("In pair %d: JSON %s should be unmarshaled to %s, got %s, %v", i, data, interval, res, err)
+		}
+		bin, err := interval.MarshalBinary()
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		res = Interval{}
+		if err := res.UnmarshalBinary(bin); err != nil || res.String() != interval.String() {
+			t.Errorf("In pair %d: binary should be unmarshaled to %s, got %s, %v", i, interval, res, err)
+		}
+		text, err := interval.MarshalText()
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		res = Interval{}
+		if err := res.UnmarshalText(text); err != nil || res.String() != interval.String() {
+			t.Errorf("In pair %d: text %s should be unmarshaled to %s, got %s, %v", i, text, interval, res, err)
+		}
+	}
+}
+
+func TestIntervalMarshalTextZero(t *testing.T) {
+	text, err := Interval{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("Zero interval should be marshaled to empty text, got %q, %v", text, err)
+	}
+	res := NewInterval(One(), One())
+	if err := res.UnmarshalText(text); err != nil || res.op != nil {
+		t.Errorf("Empty text should be unmarshaled to zero interval, got %v, %v", res.op, err)
+	}
+	if err := res.UnmarshalText([]byte(" ")); err == nil {
+		t.Errorf("Blank text should not be unmarshaled, got %s", res)
+	}
+}
+
+func TestIntervalMarshalResume(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	expr := x.Add(y).Mul(NewInterval(NewInt(2), NewInt(3)))
+	partial := expr.Solve(VarMap{"x": NewInterval(NewInt(1), NewInt(2))})
+	data, err := json.Marshal(partial)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	var resumed Interval
+	if err := json.Unmarshal(data, &resumed); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	varMap := VarMap{"y": NewInterval(NewInt(0), NewInt(1))}
+	want := expr.Solve(VarMap{"x": NewInterval(NewInt(1), NewInt(2)), "y": NewInterval(NewInt(0), NewInt(1))})
+	if got := resumed.Solve(varMap); got.String() != want.String() {
+		t.Errorf("Resumed expression should be solved to %s, got %s", want, got)
+	}
+}
+
+func TestIntervalUnmarshalJSONErrors(t *testing.T) {
+	var testPairs = []string{
+		`{"op": "foo"}`,
+		`{"op": "const", "left": "1"}`,
+		`{"op": "const", "left": "a", "right": "1"}`,
+		`{"op": "var", "name": "1x"}`,
+		`{"op": "var", "name": "Inf"}`,
+		`{"op": "var", "name": "NaN"}`,
+		`{"op": "add", "operands": [{"op": "var", "name": "x"}]}`,
+		`{"op": "mul", "const": {"op": "var", "name": "x"}}`,
+		`{"op": "set", "pieces": [{"op": "var", "name": "x"}]}`,
+		`[1, 2]`,
+		`{"op": "pow", "n": 2}`,
+		`{"op": "sin"}`,
+		`{"op": "max", "operands": [{"op": "var", "name": "x"}]}`,
+		`{"op": "clamp", "operands": [{"op": "var", "name": "x"}]}`,
+		`{"op": "root", "operands": [{"op": "var", "name": "x"}], "n": 1}`,
+		`{"op": "root", "operands": [{"op": "var", "name": "x"}], "n": 1001}`,
+		`{"op": "root", "operands": [{"op": "var", "name": "x"}], "n": 2, "denom": -1000}`,
+		`{"op": "pow", "operands": [{"op": "var", "name": "x"}], "n": 200000000}`,
+	}
+	for i, data := range testPairs {
+		var res Interval
+		if err := json.Unmarshal([]byte(data), &res); err == nil {
+			t.Errorf("In pair %d: %s should not be unmarshaled, got %s", i, data, res)
+		}
+	}
+}
+
+func TestIntervalUnmarshalBinaryDepth(t *testing.T) {
+	x, _ := Var("x")
+	for _, depth := range []int{maxDepth, maxDepth + 1} {
+		expr := x
+		for j := 1; j < depth; j++ {
+			expr = expr.Sin()
+		}
+		data, err := expr.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		var res Interval
+		err = res.UnmarshalBinary(data)
+		if depth <= maxDepth && err != nil {
+			t.Errorf("Expression nested %d times should be unmarshaled, got %v", depth, err)
+		}
+		if depth > maxDepth && err != errBadBinary {
+			t.Errorf("Expression nested %d times should not be unmarshaled, got %v", depth, err)
+		}
+	}
+}
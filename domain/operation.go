@@ -10,6 +10,9 @@ type operation interface {
 	priority() byte
 	Solve(varMap VarMap) operation
 	String() string
+	node() exprNode
+	children() []operation
+	withChildren(children []operation) operation
 }
 
 type group interface {
@@ -42,73 +45,94 @@ func (o mul) priority() byte {
 	return 2
 }
 
+func (o mul) children() []operation {
+	return append(append([]operation{}, o.operands...), o.invOperands...)
+}
+
+func (o mul) withChildren(children []operation) operation {
+	o.operands = children[:len(o.operands):len(o.operands)]
+	o.invOperands = children[len(o.operands):]
+	return o
+}
+
 func (o mul) Solve(varMap VarMap) operation {
 	var res = mul{
 		k: o.k,
 	}
-	if o.k.left.cmp(Zero()) == 0 && o.k.right.cmp(Zero()) == 0 {
-		return constInterval{Zero(), Zero()}
-	}
+	var sets, invSets []multiInterval
 	for _, operand := range o.operands {
-		if i, ok := operand.Solve(varMap).(constInterval); ok {
-			if i.left.cmp(Zero()) == 0 && i.right.cmp(Zero()) == 0 {
-				return constInterval{Zero(), Zero()}
-			}
+		solved := operand.Solve(varMap)
+		if i, ok := solved.(constInterval); ok {
 			res.k = res.k.mulConst(i)
 			continue
 		}
-		if m, ok := operand.Solve(varMap).(mul); ok {
-			if m.k.left.cmp(Zero()) == 0 && m.k.right.cmp(Zero()) == 0 {
-				return constInterval{Zero(), Zero()}
-			}
+		if set, ok := solved.(multiInterval); ok {
+			sets = append(sets, set)
+			continue
+		}
+		if m, ok := solved.(mul); ok {
 			res.k = res.k.mulConst(m.k)
 			res.operands = append(res.operands, m.operands...)
 			res.invOperands = append(res.invOperands, m.invOperands...)
 			continue
 		}
-		res.operands = append(res.operands, operand.Solve(varMap))
+		res.operands = append(res.operands, solved)
 	}
 	for _, operand := range o.invOperands {
-		if i, ok := operand.Solve(varMap).(constInterval); ok {
+		solved := operand.Solve(varMap)
+		if i, ok := solved.(constInterval); ok {
 			res.k = res.k.divConst(i)
 			continue
 		}
-		if m, ok := operand.Solve(varMap).(mul); ok {
+		if set, ok := solved.(multiInterval); ok {
+			invSets = append(invSets, set)
+			continue
+		}
+		if m, ok := solved.(mul); ok {
 			res.k = res.k.divConst(m.k)
 			res.operands = append(res.operands, m.invOperands...)
 			res.invOperands = append(res.invOperands, m.operands...)
 			continue
 		}
-		res.invOperands = append(res.invOperands, operand.Solve(varMap))
+		res.invOperands = append(res.invOperands, solved)
+	}
+	if len(sets) != 0 || len(invSets) != 0 {
+		folded := foldSets(res.k, sets, invSets, constInterval.mulConst, constInterval.divExtended)
+		if k, ok := folded.(constInterval); ok {
+			res.k = k
+		} else if len(res.operands) == 0 && len(res.invOperands) == 0 {
+			return folded
+		} else {
+			res.k = res.neutral()
+			res.operands = append([]operation{folded}, res.operands...)
+		}
 	}
-	if len(res.operands) == 0 && len(res.invOperands) == 0 {
+	if res.k.absorbing() || len(res.operands) == 0 && len(res.invOperands) == 0 {
 		return res.k
 	}
+	if res.k.isZero() {
+		return constInterval{Zero(), Zero()}
+	}
 	return res
 }
 
 func (o mul) String() string {
+	if o.k.equal(o.neutral()) && len(o.operands) == 1 && len(o.invOperands) == 0 {
+		//neutral wrapper of single operand, which is created by Mul and Div
+		return o.operands[0].String()
+	}
 	var res string
-	if o.k != o.neutral() || len(o.operands) == 0 {
+	if !o.k.equal(o.neutral()) || len(o.operands) == 0 {
 		res += o.k.String()
 	}
 	for _, operand := range o.operands {
 		if len(res) != 0 {
 			res += " * "
 		}
-		if operand.priority() < o.priority() {
-			res += "(" + operand.String() + ")"
-		} else {
-			res += operand.String()
-		}
+		res += wrap(operand, o.priority(), false)
 	}
 	for _, operand := range o.invOperands {
-		res += " / "
-		if operand.priority() < o.priority() {
-			res += "(" + operand.String() + ")"
-		} else {
-			res += operand.String()
-		}
+		res += " / " + wrap(operand, o.priority(), true)
 	}
 	return res
 }
@@ -151,66 +175,91 @@ func (o add) priority() byte {
 	return 1
 }
 
+func (o add) children() []operation {
+	return append(append([]operation{}, o.operands...), o.invOperands...)
+}
+
+func (o add) withChildren(children []operation) operation {
+	o.operands = children[:len(o.operands):len(o.operands)]
+	o.invOperands = children[len(o.operands):]
+	return o
+}
+
 func (o add) Solve(varMap VarMap) operation {
 	var res = add{
 		m: o.m,
 	}
+	var sets, invSets []multiInterval
 	for _, operand := range o.operands {
-		if i, ok := operand.Solve(varMap).(constInterval); ok {
+		solved := operand.Solve(varMap)
+		if i, ok := solved.(constInterval); ok {
 			res.m = res.m.addConst(i)
 			continue
 		}
-		if a, ok := operand.Solve(varMap).(add); ok {
+		if set, ok := solved.(multiInterval); ok {
+			sets = append(sets, set)
+			continue
+		}
+		if a, ok := solved.(add); ok {
 			res.m = res.m.addConst(a.m)
 			res.operands = append(res.operands, a.operands...)
 			res.invOperands = append(res.invOperands, a.invOperands...)
 			continue
 		}
-		res.operands = append(res.operands, operand.Solve(varMap))
+		res.operands = append(res.operands, solved)
 	}
 	for _, operand := range o.invOperands {
-		if i, ok := operand.Solve(varMap).(constInterval); ok {
+		solved := operand.Solve(varMap)
+		if i, ok := solved.(constInterval); ok {
 			res.m = res.m.subConst(i)
 			continue
 		}
-		if a, ok := operand.Solve(varMap).(add); ok {
+		if set, ok := solved.(multiInterval); ok {
+			invSets = append(invSets, set)
+			continue
+		}
+		if a, ok := solved.(add); ok {
 			res.m = res.m.subConst(a.m)
 			res.operands = append(res.operands, a.invOperands...)
 			res.invOperands = append(res.invOperands, a.operands...)
 			continue
 		}
-		res.invOperands = append(res.invOperands, operand.Solve(varMap))
+		res.invOperands = append(res.invOperands, solved)
 	}
-	if len(res.operands) == 0 && len(res.invOperands) == 0 {
+	if len(sets) != 0 || len(invSets) != 0 {
+		folded := foldSets(res.m, sets, invSets, constInterval.addConst, subPieces)
+		if m, ok := folded.(constInterval); ok {
+			res.m = m
+		} else if len(res.operands) == 0 && len(res.invOperands) == 0 {
+			return folded
+		} else {
+			res.m = res.neutral()
+			res.operands = append([]operation{folded}, res.operands...)
+		}
+	}
+	if res.m.absorbing() || len(res.operands) == 0 && len(res.invOperands) == 0 {
 		return res.m
 	}
 	return res
 }
 
 func (o add) String() string {
+	if o.m.equal(o.neutral()) && len(o.operands) == 1 && len(o.invOperands) == 0 {
+		//neutral wrapper of single operand, which is created by Add and Sub
+		return o.operands[0].String()
+	}
 	var res string
-	if o.m != o.neutral() {
+	if !o.m.equal(o.neutral()) || len(o.operands) == 0 {
 		res += o.m.String()
 	}
 	for _, operand := range o.operands {
 		if len(res) != 0 {
 			res += " + "
 		}
-		if operand.priority() < o.priority() {
-			res += "(" + operand.String() + ")"
-		} else {
-			res += operand.String()
-		}
+		res += wrap(operand, o.priority(), false)
 	}
 	for _, operand := range o.invOperands {
-		if len(res) != 0 {
-			res += " - "
-		}
-		if operand.priority() < o.priority() {
-			res += "(" + operand.String() + ")"
-		} else {
-			res += operand.String()
-		}
+		res += " - " + wrap(operand, o.priority(), true)
 	}
 	return res
 }
@@ -221,10 +270,159 @@ func (o add) mul(multiplier operation) operation {
 		operands: []operation{o},
 	}
 	res.operands = append(res.operands, multiplier)
-	return o
+	return res
 }
 
 func (o add) add(addednd operation) operation {
 	o.operands = append(o.operands, addednd)
 	return o
 }
+
+//wrap returns string representation of operand of operation with priority p.
+//Operand is wrapped in parentheses if it binds weaker than operation,
+//or it binds equally and is inversed or has inversed operands itself
+func wrap(operand operation, p byte, inversed bool) string {
+	if operand.priority() < p || operand.priority() == p && (inversed || hasInvOperands(operand)) {
+		return "(" + operand.String() + ")"
+	}
+	return operand.String()
+}
+
+func hasInvOperands(o operation) bool {
+	switch o := o.(type) {
+	case add:
+		return len(o.invOperands) != 0
+	case mul:
+		return len(o.invOperands) != 0
+	}
+	return false
+}
+
+//funcKind is kind of elementary function in function node
+type funcKind byte
+
+const (
+	funcExp funcKind = iota
+	funcLog
+	funcSin
+	funcCos
+	funcTan
+	funcAtan
+	funcAbs
+	funcSign
+)
+
+var funcNames = [...]string{
+	funcExp:  "exp",
+	funcLog:  "log",
+	funcSin:  "sin",
+	funcCos:  "cos",
+	funcTan:  "tan",
+	funcAtan: "atan",
+	funcAbs:  "abs",
+	funcSign: "sign",
+}
+
+//funcByName returns kind of function with passed name, ok is false if there is no such function
+func funcByName(name string) (funcKind, bool) {
+	for kind, funcName := range funcNames {
+		if funcName == name {
+			return funcKind(kind), true
+		}
+	}
+	return 0, false
+}
+
+func (k funcKind) String() string {
+	return funcNames[k]
+}
+
+//Exp returns interval containing e^x for every x in current interval
+func (i Interval) Exp() Interval {
+	return i.apply(funcExp)
+}
+
+//Log returns interval containing natural logarithm of every positive point of current interval.
+//Non-positive part of interval is clipped, so logarithm of non-positive interval is empty
+func (i Interval) Log() Interval {
+	return i.apply(funcLog)
+}
+
+//Sin returns interval containing sine of every point of current interval
+func (i Interval) Sin() Interval {
+	return i.apply(funcSin)
+}
+
+//Cos returns interval containing cosine of every point of current interval
+func (i Interval) Cos() Interval {
+	return i.apply(funcCos)
+}
+
+//Tan returns interval containing tangent of every point of current interval.
+//If interval contains pole of tangent result is [-Inf, Inf]
+func (i Interval) Tan() Interval {
+	return i.apply(funcTan)
+}
+
+//Atan returns interval containing arctangent of every point of current interval
+func (i Interval) Atan() Interval {
+	return i.apply(funcAtan)
+}
+
+func (i Interval) apply(kind funcKind) Interval {
+	i.op = function{kind: kind, arg: i.op}
+	return i
+}
+
+//function is elementary or piecewise function applied to arg.
+//Bounds of solved elementary function are rounded outward to multiple of 1 / roundingDenominator
+type function struct {
+	kind funcKind
+	arg  operation
+}
+
+func (o function) priority() byte {
+	return 255
+}
+
+func (o function) children() []operation {
+	return []operation{o.arg}
+}
+
+func (o function) withChildren(children []operation) operation {
+	o.arg = children[0]
+	return o
+}
+
+func (o function) Solve(varMap VarMap) operation {
+	switch arg := o.arg.Solve(varMap).(type) {
+	case constInterval:
+		return arg.applyConst(o.kind)
+	case multiInterval:
+		var pieces []constInterval
+		for _, piece := range arg {
+			pieces = append(pieces, piece.applyConst(o.kind))
+		}
+		return newMultiInterval(pieces)
+	default:
+		return function{kind: o.kind, arg: arg}
+	}
+}
+
+func (o function) String() string {
+	return o.kind.String() + "(" + o.arg.String() + ")"
+}
+
+func (o function) mul(multiplier operation) operation {
+	return mul{
+		k:        mul{}.neutral(),
+		operands: []operation{o, multiplier},
+	}
+}
+
+func (o function) add(addend operation) operation {
+	return add{
+		m:        add{}.neutral(),
+		operands: []operation{o, addend},
+	}
+}
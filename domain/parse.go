@@ -0,0 +1,525 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+//ParseError describes syntax error in parsed expression with position of token caused it.
+//Lines and columns are counted from 1, columns are counted in characters
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+//Error returns error message with position in format line:column: message
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+//Parse parses expression in the same syntax which is returned by Interval.String, for example
+//x + x / ([5, 6] + x) * [5 / 3, 1 / 2] / x - [1, 1].
+//Expression consists of:
+//	constant intervals [a, b] with bounds like 35 / 6, -1, Inf, -Inf and NaN, bounds are normalised as in NewInterval;
+//	empty interval [];
+//	unions of intervals {[a, b], [c, d]};
+//	numbers like 2, 0.5 or 1.5e-7, which are point intervals;
+//	variables, which names contain only letters and digits and start from letter;
+//	operations +, -, *, / with usual priorities, unary minus and parentheses;
+//	integer powers like x^2 or x^-1, which bind stronger than unary minus, so -x^2 is -(x^2);
+//	roots sqrt(x) and root(x, n) with integer n, root(x, n, d) rounds inexact bounds to multiple of 1 / d;
+//	elementary functions exp, log, sin, cos, tan and atan like sin(x);
+//	piecewise functions abs(x), sign(x), min(x, y, ...), max(x, y, ...) and clamp(x, lo, hi).
+//Returns *ParseError if expression is malformed or nested deeper than 10000 levels
+func Parse(s string) (Interval, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return Interval{}, err
+	}
+	p := &parser{tokens: tokens}
+	op, err := p.expr()
+	if err != nil {
+		return Interval{}, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return Interval{}, t.errorf("unexpected %q", t.text)
+	}
+	return Interval{op: op}, nil
+}
+
+type tokenKind byte
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenPunct
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t token) errorf(format string, args ...interface{}) error {
+	return &ParseError{
+		Line:   t.line,
+		Column: t.column,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (t token) is(text string) bool {
+	return t.kind == tokenPunct && t.text == text
+}
+
+const punctuation = "[]{}(),+-*/^"
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	line, column := 1, 1
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		start := token{line: line, column: column}
+		switch {
+		case c == '\n':
+			line++
+			column = 1
+			i++
+			continue
+		case unicode.IsSpace(c):
+			column++
+			i++
+			continue
+		case isDigit(c):
+			start.kind = tokenNumber
+			j := scanDigits(runes, i)
+			if j < len(runes)-1 && runes[j] == '.' && isDigit(runes[j+1]) {
+				j = scanDigits(runes, j+1)
+			}
+			if j < len(runes)-1 && (runes[j] == 'e' || runes[j] == 'E') {
+				k := j + 1
+				if runes[k] == '+' || runes[k] == '-' {
+					k++
+				}
+				if k < len(runes) && isDigit(runes[k]) {
+					j = scanDigits(runes, k)
+				}
+			}
+			start.text = string(runes[i:j])
+		case c < unicode.MaxASCII && unicode.IsLetter(c):
+			start.kind = tokenIdent
+			j := i
+			for j < len(runes) && runes[j] < unicode.MaxASCII && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			start.text = string(runes[i:j])
+		case containsRune(punctuation, c):
+			start.kind = tokenPunct
+			start.text = string(c)
+		default:
+			return nil, start.errorf("unexpected character %q", c)
+		}
+		tokens = append(tokens, start)
+		length := len([]rune(start.text))
+		i += length
+		column += length
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of expression", line: line, column: column}), nil
+}
+
+func scanDigits(runes []rune, i int) int {
+	for i < len(runes) && isDigit(runes[i]) {
+		i++
+	}
+	return i
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+func containsRune(s string, c rune) bool {
+	for _, r := range s {
+		if r == c {
+			return true
+		}
+	}
+	return false
+}
+
+//maxDepth is the largest nesting depth of parsed and unmarshaled expressions like in encoding/json,
+//so deeply nested input can not overflow stack of recursive descent
+const maxDepth = 10000
+
+//parser is recursive descent parser, depth is current nesting depth of expression
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.next(); !t.is(text) {
+		return t.errorf("expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+//enter increases nesting depth, it returns error if depth is greater than maxDepth
+func (p *parser) enter() error {
+	if p.depth == maxDepth {
+		return p.peek().errorf("expression is nested deeper than %d", maxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+//expr parses sum of terms
+func (p *parser) expr() (operation, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	first, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	res := add{
+		m:        add{}.neutral(),
+		operands: []operation{first},
+	}
+	for p.peek().is("+") || p.peek().is("-") {
+		sign := p.next()
+		operand, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if sign.is("+") {
+			res.operands = append(res.operands, operand)
+		} else {
+			res.invOperands = append(res.invOperands, operand)
+		}
+	}
+	if len(res.operands) == 1 && len(res.invOperands) == 0 {
+		return first, nil
+	}
+	return res, nil
+}
+
+//term parses product of factors
+func (p *parser) term() (operation, error) {
+	first, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	res := mul{
+		k:        mul{}.neutral(),
+		operands: []operation{first},
+	}
+	for p.peek().is("*") || p.peek().is("/") {
+		sign := p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if sign.is("*") {
+			res.operands = append(res.operands, operand)
+		} else {
+			res.invOperands = append(res.invOperands, operand)
+		}
+	}
+	if len(res.operands) == 1 && len(res.invOperands) == 0 {
+		return first, nil
+	}
+	return res, nil
+}
+
+//unary parses factor with optional unary minus, which is parsed as subtraction from [0, 0]
+func (p *parser) unary() (operation, error) {
+	if !p.peek().is("-") {
+		return p.power()
+	}
+	p.next()
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return add{
+		m:           add{}.neutral(),
+		invOperands: []operation{operand},
+	}, nil
+}
+
+//power parses primary raised to integer powers, powers are applied from left to right
+func (p *parser) power() (operation, error) {
+	base, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	res := Interval{op: base}
+	//every power is nested into previous one
+	defer func(depth int) {
+		p.depth = depth
+	}(p.depth)
+	for p.peek().is("^") {
+		p.next()
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		n, err := p.integer()
+		if err != nil {
+			return nil, err
+		}
+		res = res.Pow(n)
+	}
+	return res.op, nil
+}
+
+//integer parses integer with optional minus
+func (p *parser) integer() (int, error) {
+	negative := false
+	if p.peek().is("-") {
+		p.next()
+		negative = true
+	}
+	t := p.next()
+	n, err := strconv.Atoi(t.text)
+	if t.kind != tokenNumber || err != nil {
+		return 0, t.errorf("expected integer, got %q", t.text)
+	}
+	if n > maxPower {
+		return 0, t.errorf("integer %s is greater than %d", t.text, maxPower)
+	}
+	if negative {
+		n = -n
+	}
+	return n, nil
+}
+
+//call parses function call: sqrt(x), root(x, n) or root(x, n, d) with integers n and d, elementary or piecewise function like exp(x),
+//min(x, y, ...), max(x, y, ...) or clamp(x, lo, hi)
+func (p *parser) call() (operation, error) {
+	name := p.next()
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	op, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	arg := Interval{op: op}
+	if name.text == "root" {
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		t := p.peek()
+		n, err := p.integer()
+		if err != nil {
+			return nil, err
+		}
+		if n > maxRootDegree || n < -maxRootDegree {
+			return nil, t.errorf("absolute value of root degree %d is greater than %d", n, maxRootDegree)
+		}
+		denom := int64(roundingDenominator)
+		if p.peek().is(",") {
+			p.next()
+			t := p.next()
+			denom, err = strconv.ParseInt(t.text, 10, 64)
+			if t.kind != tokenNumber || err != nil || denom <= 0 {
+				return nil, t.errorf("expected positive rounding denominator, got %q", t.text)
+			}
+		}
+		return arg.RootPrec(n, denom).op, p.expect(")")
+	}
+	var rest []Interval
+	for p.peek().is(",") {
+		p.next()
+		op, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		rest = append(rest, Interval{op: op})
+	}
+	switch name.text {
+	case "min":
+		arg = Min(arg, rest...)
+	case "max":
+		arg = Max(arg, rest...)
+	case "clamp":
+		if len(rest) != 2 {
+			return nil, name.errorf("clamp expects 3 arguments, got %d", len(rest)+1)
+		}
+		arg = arg.Clamp(rest[0], rest[1])
+	case "sqrt":
+		if len(rest) != 0 {
+			return nil, name.errorf("sqrt expects 1 argument, got %d", len(rest)+1)
+		}
+		arg = arg.Sqrt()
+	default:
+		kind, ok := funcByName(name.text)
+		if !ok {
+			return nil, name.errorf("unknown function %q", name.text)
+		}
+		if len(rest) != 0 {
+			return nil, name.errorf("%s expects 1 argument, got %d", name.text, len(rest)+1)
+		}
+		arg = arg.apply(kind)
+	}
+	return arg.op, p.expect(")")
+}
+
+func (p *parser) primary() (operation, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenNumber || t.text == "Inf" || t.text == "NaN":
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		return NewInterval(v, v).op, nil
+	case t.kind == tokenIdent && p.tokens[p.pos+1].is("("):
+		return p.call()
+	case t.kind == tokenIdent:
+		p.next()
+		return variable{varName: t.text}, nil
+	case t.is("["):
+		return p.interval()
+	case t.is("{"):
+		return p.set()
+	case t.is("("):
+		p.next()
+		op, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return op, p.expect(")")
+	}
+	return nil, t.errorf("unexpected %q", t.text)
+}
+
+//interval parses [left, right] or empty interval []
+func (p *parser) interval() (operation, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	if p.peek().is("]") {
+		p.next()
+		return emptyInterval(), nil
+	}
+	left, err := p.bound()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	right, err := p.bound()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return NewInterval(left, right).op, nil
+}
+
+//set parses union of intervals {[a, b], [c, d]}
+func (p *parser) set() (operation, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var pieces []constInterval
+	for {
+		t := p.peek()
+		piece, err := p.interval()
+		if err != nil {
+			return nil, err
+		}
+		c := piece.(constInterval)
+		if c.isUndefined() {
+			return nil, t.errorf("undefined interval in union")
+		}
+		pieces = append(pieces, c)
+		if !p.peek().is(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return newMultiInterval(pieces), nil
+}
+
+//bound parses bound of interval: optional minus and number or fraction like 35 / 6
+func (p *parser) bound() (*Value, error) {
+	negative := false
+	if p.peek().is("-") {
+		p.next()
+		negative = true
+	}
+	v, err := p.number()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().is("/") {
+		p.next()
+		t := p.peek()
+		denom, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if denom.IsInf() || denom.IsNaN() {
+			return nil, t.errorf("denominator should be finite number")
+		}
+		v.div(v, denom)
+	}
+	if negative {
+		v.Neg(v)
+	}
+	return v, nil
+}
+
+//number parses unsigned number, Inf or NaN
+func (p *parser) number() (*Value, error) {
+	t := p.next()
+	switch {
+	case t.text == "Inf":
+		return Inf(), nil
+	case t.text == "NaN":
+		return NaN(), nil
+	case t.kind == tokenNumber:
+		v, err := ParseValue(t.text)
+		if err != nil {
+			return nil, t.errorf("bad number %q", t.text)
+		}
+		return v, nil
+	}
+	return nil, t.errorf("expected number, got %q", t.text)
+}
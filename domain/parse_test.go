@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	var testPairs = []string{
+		"[-1, -1] + [1 / 2, 5 / 3] * x / ([5, 6] + x) / x + x",
+		"x - (y + [1, 2])",
+		"x / (y * z)",
+		"x * (y / z) * [2, 3]",
+		"x + (y - z) + [1, 2]",
+		"[0, 0] - x",
+		"[1, 1] / x",
+		"[-Inf, 35 / 6] * x",
+		"{[1, 2], [3, 4]} + x",
+		"[] * x",
+		"[NaN, NaN]",
+		"x^2 + (x - y)^3 / x^4",
+		"(x^2)^3",
+		"[1, 1] / x^2",
+		"sqrt(x + y) * root(x, 3)^2",
+		"root(x, 2, 1000) - root(x, 3, 7)",
+		"exp(x) + sin(x)^2 / cos(tan(atan(y))) - log(x * y)",
+		"max(x, min(y, [0, 1]), abs(x)) * sign(y) + clamp(x, [0, 0], y)",
+		x.Add(y).Mul(x).Sub(y.Div(x)).String(),
+		x.Sub(y.Add(x)).Div(y.Mul(x)).String(),
+		x.Add(y).Div(x).String(),
+		NewInterval(Zero(), Zero()).Sub(x).String(),
+		NewInterval(One(), One()).Div(x).String(),
+		y.Mul(NewInterval(One(), One()).Div(x)).String(),
+		y.Sub(NewInterval(Zero(), Zero()).Sub(x)).String(),
+	}
+	if s := NewInterval(Zero(), Zero()).Sub(x).String(); s != "[0, 0] - x" {
+		t.Errorf("[0, 0] - x should be printed without parentheses, got %s", s)
+	}
+	for i, s := range testPairs {
+		parsed, err := Parse(s)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if parsed.String() != s {
+			t.Errorf("In pair %d: %s should be parsed to same expression, got %s", i, s, parsed)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	var testPairs = []struct {
+		expr string
+		res  string
+	}{
+		{
+			expr: "x + x / ([5, 6] + x) * [5/3, 1/2] / x - [1, 1]",
+			res:  "[-1, -1] + x + [1 / 2, 5 / 3] * x / ([5, 6] + x) / x",
+		},
+		{
+			expr: "[1,2]+[3 , 4]",
+			res:  "[4, 6]",
+		},
+		{
+			expr: "2 * [1, 2] - 0.5",
+			res:  "[3 / 2, 7 / 2]",
+		},
+		{
+			expr: "-[1, 2] * 3",
+			res:  "[-6, -3]",
+		},
+		{
+			expr: "[-Inf, -1 / 2] - -1",
+			res:  "[-Inf, 1 / 2]",
+		},
+		{
+			expr: "1.5e-1 * [2, 4] + [-2.5E1, 1e2]",
+			res:  "[-247 / 10, 503 / 5]",
+		},
+		{
+			expr: "((x))",
+			res:  "x",
+		},
+		{
+			expr: "{[3, 4], [1, 2], [2, 5 / 2]}",
+			res:  "{[1, 5 / 2], [3, 4]}",
+		},
+		{
+			expr: "-[-1, 2]^2 + 2^-1",
+			res:  "[-7 / 2, 1 / 2]",
+		},
+		{
+			expr: "[]",
+			res:  "[]",
+		},
+	}
+	for i, pair := range testPairs {
+		parsed, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := parsed.Solve(VarMap{}); res.String() != pair.res {
+			t.Errorf("In pair %d: %s should be parsed and solved to %s, got %s", i, pair.expr, pair.res, res)
+		}
+	}
+}
+
+func TestParseSolve(t *testing.T) {
+	x, _ := Var("x")
+	built := x.Add(
+		x.Div(
+			NewInterval(NewFrac(5, 1), NewFrac(6, 1)).Add(x),
+		).Mul(
+			NewInterval(NewFrac(1, 2), NewFrac(5, 3)),
+		).Div(x),
+	).Sub(NewInterval(NewFrac(1, 1), NewFrac(1, 1)))
+	parsed, err := Parse("x + x / ([5, 6] + x) * [1 / 2, 5 / 3] / x - [1, 1]")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	varMap := VarMap{"x": NewInterval(NewFrac(1, 1), NewFrac(2, 1))}
+	if a, b := built.Solve(varMap), parsed.Solve(varMap); a.String() != b.String() {
+		t.Errorf("Parsed expression should be solved as built one: %s, got %s", a, b)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	var testPairs = []struct {
+		expr   string
+		line   int
+		column int
+	}{
+		{expr: "x +", line: 1, column: 4},
+		{expr: "x + [1, 2", line: 1, column: 10},
+		{expr: "x + [1; 2]", line: 1, column: 7},
+		{expr: "x\n  + (y", line: 2, column: 7},
+		{expr: "x\n+ y)", line: 2, column: 4},
+		{expr: "[1, 2 / Inf]", line: 1, column: 9},
+		{expr: "{[1, 2], x}", line: 1, column: 10},
+		{expr: "x $ y", line: 1, column: 3},
+		{expr: "", line: 1, column: 1},
+		{expr: "x^y", line: 1, column: 3},
+		{expr: "x^1.5", line: 1, column: 3},
+		{expr: "root(x, y)", line: 1, column: 9},
+		{expr: "foo(x)", line: 1, column: 1},
+		{expr: "x + clamp(x, y)", line: 1, column: 5},
+		{expr: "sin(x, y)", line: 1, column: 1},
+		{expr: "[3, 3]^200000000", line: 1, column: 8},
+		{expr: "x^-1000001", line: 1, column: 4},
+		{expr: "root(x, 1001)", line: 1, column: 9},
+		{expr: "root(x, -1001)", line: 1, column: 9},
+		{expr: "root(x, 2, 0)", line: 1, column: 12},
+		{expr: "root(x, 2, y)", line: 1, column: 12},
+	}
+	for i, pair := range testPairs {
+		_, err := Parse(pair.expr)
+		parseErr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("In pair %d: %q should fail with ParseError, got %v", i, pair.expr, err)
+			continue
+		}
+		if parseErr.Line != pair.line || parseErr.Column != pair.column {
+			t.Errorf("In pair %d: %q should fail at %d:%d, got %v", i, pair.expr, pair.line, pair.column, err)
+		}
+	}
+	_, err := Parse("root(x, -1001)")
+	if msg := "1:9: absolute value of root degree -1001 is greater than 1000"; err == nil || err.Error() != msg {
+		t.Errorf("Negative root degree should fail with %q, got %v", msg, err)
+	}
+}
+
+func TestParseDepth(t *testing.T) {
+	var testPairs = []struct {
+		expr  string
+		valid bool
+	}{
+		{expr: strings.Repeat("(", maxDepth-1) + "x" + strings.Repeat(")", maxDepth-1), valid: true},
+		{expr: strings.Repeat("(", maxDepth) + "x" + strings.Repeat(")", maxDepth), valid: false},
+		{expr: strings.Repeat("-", maxDepth-1) + "x", valid: true},
+		{expr: strings.Repeat("-", 1000000) + "x", valid: false},
+		{expr: "x" + strings.Repeat("^2", maxDepth-1), valid: true},
+		{expr: "x" + strings.Repeat("^2", maxDepth), valid: false},
+		{expr: strings.Repeat("sin(", maxDepth) + "x" + strings.Repeat(")", maxDepth), valid: false},
+	}
+	for i, pair := range testPairs {
+		_, err := Parse(pair.expr)
+		if _, ok := err.(*ParseError); pair.valid && err != nil || !pair.valid && !ok {
+			t.Errorf("In pair %d: expression should be valid: %v, got error %v", i, pair.valid, err)
+		}
+	}
+}
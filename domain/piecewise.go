@@ -0,0 +1,239 @@
+package domain
+
+import "strings"
+
+//Abs returns interval of absolute values of points of current interval, [-2, 1].Abs() = [0, 2]
+func (i Interval) Abs() Interval {
+	return i.apply(funcAbs)
+}
+
+//Sign returns interval of signs of points of current interval, which is subinterval of [-1, 1].
+//For example [-2, 0].Sign() = [-1, 0] and [1, 2].Sign() = [1, 1]
+func (i Interval) Sign() Interval {
+	return i.apply(funcSign)
+}
+
+//Min returns interval of minimums of points of passed intervals
+//Min([a1, b1], [a2, b2], ..., [an, bn]) = [min(a1, ..., an), min(b1, ..., bn)]
+func Min(a Interval, rest ...Interval) Interval {
+	return newExtremum(false, a, rest)
+}
+
+//Max returns interval of maximums of points of passed intervals
+//Max([a1, b1], [a2, b2], ..., [an, bn]) = [max(a1, ..., an), max(b1, ..., bn)]
+func Max(a Interval, rest ...Interval) Interval {
+	return newExtremum(true, a, rest)
+}
+
+func newExtremum(max bool, a Interval, rest []Interval) Interval {
+	if len(rest) == 0 {
+		return a
+	}
+	res := extremum{max: max, operands: []operation{a.op}}
+	for _, i := range rest {
+		res.operands = append(res.operands, i.op)
+	}
+	return Interval{op: res}
+}
+
+//Clamp returns current interval limited with lo and hi, which is Min(Max(i, lo), hi).
+//If lo is greater than hi result is hi
+func (i Interval) Clamp(lo, hi Interval) Interval {
+	i.op = clamp{x: i.op, lo: lo.op, hi: hi.op}
+	return i
+}
+
+//extremum is maximum of operands if max is true, else it is minimum of operands
+type extremum struct {
+	max      bool
+	operands []operation
+}
+
+func (o extremum) priority() byte {
+	return 255
+}
+
+func (o extremum) children() []operation {
+	return o.operands
+}
+
+func (o extremum) withChildren(children []operation) operation {
+	o.operands = children
+	return o
+}
+
+//f returns operation on constant intervals computing extremum
+func (o extremum) f() func(a, b constInterval) constInterval {
+	if o.max {
+		return constInterval.maxConst
+	}
+	return constInterval.minConst
+}
+
+func (o extremum) Solve(varMap VarMap) operation {
+	var folded []constInterval
+	hasConst := false
+	res := extremum{max: o.max}
+	var collect func(op operation)
+	collect = func(op operation) {
+		if pieces, ok := piecesOf(op); ok {
+			if hasConst {
+				folded = lift(folded, pieces, o.f())
+			} else {
+				folded, hasConst = pieces, true
+			}
+			return
+		}
+		if e, ok := op.(extremum); ok && e.max == o.max {
+			for _, operand := range e.operands {
+				collect(operand)
+			}
+			return
+		}
+		res.operands = append(res.operands, op)
+	}
+	for _, operand := range o.operands {
+		collect(operand.Solve(varMap))
+	}
+	if !hasConst {
+		if len(res.operands) == 1 {
+			return res.operands[0]
+		}
+		return res
+	}
+	k := newMultiInterval(folded)
+	if c, ok := k.(constInterval); ok && c.absorbing() || len(res.operands) == 0 {
+		return k
+	}
+	res.operands = append([]operation{k}, res.operands...)
+	return res
+}
+
+func (o extremum) String() string {
+	name := "min"
+	if o.max {
+		name = "max"
+	}
+	var operands []string
+	for _, operand := range o.operands {
+		operands = append(operands, operand.String())
+	}
+	return name + "(" + strings.Join(operands, ", ") + ")"
+}
+
+func (o extremum) mul(multiplier operation) operation {
+	return mul{
+		k:        mul{}.neutral(),
+		operands: []operation{o, multiplier},
+	}
+}
+
+func (o extremum) add(addend operation) operation {
+	return add{
+		m:        add{}.neutral(),
+		operands: []operation{o, addend},
+	}
+}
+
+//clamp is x limited with lo and hi
+type clamp struct {
+	x, lo, hi operation
+}
+
+func (o clamp) priority() byte {
+	return 255
+}
+
+func (o clamp) children() []operation {
+	return []operation{o.x, o.lo, o.hi}
+}
+
+func (o clamp) withChildren(children []operation) operation {
+	return clamp{x: children[0], lo: children[1], hi: children[2]}
+}
+
+func (o clamp) Solve(varMap VarMap) operation {
+	res := clamp{x: o.x.Solve(varMap), lo: o.lo.Solve(varMap), hi: o.hi.Solve(varMap)}
+	x, xOk := piecesOf(res.x)
+	lo, loOk := piecesOf(res.lo)
+	hi, hiOk := piecesOf(res.hi)
+	if !xOk || !loOk || !hiOk {
+		return res
+	}
+	return newMultiInterval(lift(lift(x, lo, constInterval.maxConst), hi, constInterval.minConst))
+}
+
+func (o clamp) String() string {
+	return "clamp(" + o.x.String() + ", " + o.lo.String() + ", " + o.hi.String() + ")"
+}
+
+func (o clamp) mul(multiplier operation) operation {
+	return mul{
+		k:        mul{}.neutral(),
+		operands: []operation{o, multiplier},
+	}
+}
+
+func (o clamp) add(addend operation) operation {
+	return add{
+		m:        add{}.neutral(),
+		operands: []operation{o, addend},
+	}
+}
+
+//piecesOf returns pieces of solved constant interval or union of intervals, ok is false for other operations
+func piecesOf(op operation) ([]constInterval, bool) {
+	switch op := op.(type) {
+	case constInterval:
+		return normalize([]constInterval{op}), true
+	case multiInterval:
+		return op, true
+	}
+	return nil, false
+}
+
+//maxConst returns [max(a.left, b.left), max(a.right, b.right)]
+func (a constInterval) maxConst(b constInterval) constInterval {
+	if a.isUndefined() || b.isUndefined() {
+		return undefinedInterval()
+	}
+	if a.isEmpty() || b.isEmpty() {
+		return emptyInterval()
+	}
+	res := a
+	if b.left.cmp(a.left) > 0 {
+		res.left = b.left
+	}
+	if b.right.cmp(a.right) > 0 {
+		res.right = b.right
+	}
+	return res
+}
+
+//minConst returns [min(a.left, b.left), min(a.right, b.right)]
+func (a constInterval) minConst(b constInterval) constInterval {
+	if a.isUndefined() || b.isUndefined() {
+		return undefinedInterval()
+	}
+	if a.isEmpty() || b.isEmpty() {
+		return emptyInterval()
+	}
+	res := a
+	if b.left.cmp(a.left) < 0 {
+		res.left = b.left
+	}
+	if b.right.cmp(a.right) < 0 {
+		res.right = b.right
+	}
+	return res
+}
+
+//absConst returns [mig(i), mag(i)]
+func (i constInterval) absConst() constInterval {
+	return constInterval{i.mig(), i.mag()}
+}
+
+//signConst returns [sign(i.left), sign(i.right)]
+func (i constInterval) signConst() constInterval {
+	return constInterval{NewInt(int64(i.left.sign())), NewInt(int64(i.right.sign()))}
+}
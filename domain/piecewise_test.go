@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestIntervalPiecewise(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	set, _ := NewIntervalSet(NewInterval(NewInt(-3), NewInt(-2)), NewInterval(NewInt(1), NewInt(2)))
+	var testPairs = []struct {
+		interval Interval
+		res      string
+	}{
+		{
+			interval: NewInterval(NewInt(-2), NewInt(1)).Abs(),
+			res:      "[0, 2]",
+		},
+		{
+			interval: NewInterval(NewFrac(-7, 2), NewInt(-1)).Abs(),
+			res:      "[1, 7 / 2]",
+		},
+		{
+			interval: NewInterval(NewInt(-2), Zero()).Sign(),
+			res:      "[-1, 0]",
+		},
+		{
+			interval: NewInterval(NewFrac(1, 3), Inf()).Sign(),
+			res:      "[1, 1]",
+		},
+		{
+			interval: Min(NewInterval(NewInt(1), NewInt(5)), NewInterval(NewInt(2), NewInt(3)), NewInterval(NewInt(4), NewInt(6))),
+			res:      "[1, 3]",
+		},
+		{
+			interval: Max(NewInterval(NewInt(1), NewInt(5)), NewInterval(NewInt(2), NewInt(3))),
+			res:      "[2, 5]",
+		},
+		{
+			interval: Max(NewInterval(NewInt(1), NewInt(5)), Empty()),
+			res:      "[]",
+		},
+		{
+			interval: Max(x, NewInterval(NewInt(1), NewInt(2)), Max(y, NewInterval(NewInt(0), NewInt(3)))),
+			res:      "max([1, 3], x, y)",
+		},
+		{
+			interval: Min(x, Max(y, NewInterval(NewInt(0), NewInt(3)))),
+			res:      "min(x, max([0, 3], y))",
+		},
+		{
+			interval: Min(x),
+			res:      "x",
+		},
+		{
+			interval: NewInterval(NewInt(-5), NewInt(5)).Clamp(NewInterval(NewInt(-1), NewInt(-1)), NewInterval(NewInt(2), NewInt(2))),
+			res:      "[-1, 2]",
+		},
+		{
+			interval: NewInterval(NewInt(3), NewInt(5)).Clamp(NewInterval(NewInt(-1), NewInt(-1)), NewInterval(NewInt(2), NewInt(2))),
+			res:      "[2, 2]",
+		},
+		{
+			interval: x.Clamp(NewInterval(Zero(), Zero()), NewInterval(One(), One())),
+			res:      "clamp(x, [0, 0], [1, 1])",
+		},
+		{
+			interval: set.Interval().Abs(),
+			res:      "[1, 3]",
+		},
+		{
+			interval: Max(set.Interval(), NewInterval(NewInt(0), NewInt(0))),
+			res:      "{[0, 0], [1, 2]}",
+		},
+	}
+	for i, pair := range testPairs {
+		if res := pair.interval.Solve(VarMap{}).String(); res != pair.res {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, pair.interval, pair.res, res)
+		}
+	}
+
+	expr := x.Clamp(NewInterval(Zero(), Zero()), y).Add(x.Abs())
+	if res := expr.String(); res != "clamp(x, [0, 0], y) + abs(x)" {
+		t.Errorf("String should be clamp(x, [0, 0], y) + abs(x), got %s", res)
+	}
+	varMap := VarMap{"x": NewInterval(NewInt(-2), NewInt(3)), "y": NewInterval(NewInt(1), NewInt(1))}
+	if res := expr.Solve(varMap).String(); res != "[0, 4]" {
+		t.Errorf("%s should be solved to [0, 4], got %s", expr, res)
+	}
+}
+
+func TestIntervalPiecewiseInclusion(t *testing.T) {
+	property := func(a, b, c inclusionCase) bool {
+		x, lo, hi := Interval{op: a.interval}, Interval{op: b.interval}, Interval{op: c.interval}
+		abs := x.Abs().Solve(VarMap{}).op.(constInterval)
+		sign := x.Sign().Solve(VarMap{}).op.(constInterval)
+		min := Min(x, lo).Solve(VarMap{}).op.(constInterval)
+		max := Max(x, lo).Solve(VarMap{}).op.(constInterval)
+		clamped := x.Clamp(lo, hi).Solve(VarMap{}).op.(constInterval)
+		minPoint, maxPoint := a.point, b.point
+		if minPoint.cmp(maxPoint) > 0 {
+			minPoint, maxPoint = maxPoint, minPoint
+		}
+		clampPoint := maxPoint
+		if clampPoint.cmp(c.point) > 0 {
+			clampPoint = c.point
+		}
+		return abs.contains(new(Value).Abs(a.point)) && sign.contains(NewInt(int64(a.point.Sign()))) &&
+			min.contains(minPoint) && max.contains(maxPoint) && clamped.contains(clampPoint)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000, Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Errorf("Piecewise operations violate inclusion property: %s", err)
+	}
+}
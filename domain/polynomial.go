@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+)
+
+//ErrNotPolynomial is returned by Polynomial if expression is not polynomial of single variable
+var ErrNotPolynomial = errors.New("expression is not polynomial of single variable")
+
+//Polynomial describes polynomial of single variable with constant interval coefficients.
+//Zero Polynomial is polynomial [0, 0] without variable
+type Polynomial struct {
+	varName string
+	//coeffs[i] is coefficient of x^i, the last one is not [0, 0]
+	coeffs []constInterval
+}
+
+//Polynomial converts expression to polynomial of its single variable.
+//Expression may contain sums, products, integer powers and division by expressions without variables,
+//subexpressions without variables are solved to constant coefficients.
+//Expression without variables is polynomial of degree 0 with empty variable name.
+//Returns ErrNotPolynomial if expression contains several variables or other operations on variable
+//and ErrNoExpression for zero Interval
+func (i Interval) Polynomial() (Polynomial, error) {
+	if i.op == nil {
+		return Polynomial{}, ErrNoExpression
+	}
+	vars := i.Vars()
+	if len(vars) > 1 {
+		return Polynomial{}, ErrNotPolynomial
+	}
+	p := Polynomial{}
+	if len(vars) == 1 {
+		p.varName = vars[0]
+	}
+	coeffs, err := coefficientsOf(i.op, p.varName)
+	if err != nil {
+		return Polynomial{}, err
+	}
+	p.coeffs = trim(coeffs)
+	return p, nil
+}
+
+//coefficientsOf returns coefficients of op as polynomial of variable name
+func coefficientsOf(op operation, name string) ([]constInterval, error) {
+	if v, ok := op.(variable); ok && v.varName == name {
+		return []constInterval{add{}.neutral(), mul{}.neutral()}, nil
+	}
+	if len(Interval{op: op}.Vars()) == 0 {
+		if c, ok := op.Solve(VarMap{}).(constInterval); ok {
+			return []constInterval{c}, nil
+		}
+		return nil, ErrNotPolynomial
+	}
+	switch o := op.(type) {
+	case add:
+		res := []constInterval{o.m}
+		for _, operand := range o.operands {
+			coeffs, err := coefficientsOf(operand, name)
+			if err != nil {
+				return nil, err
+			}
+			res = addCoefficients(res, coeffs, constInterval.addConst)
+		}
+		for _, operand := range o.invOperands {
+			coeffs, err := coefficientsOf(operand, name)
+			if err != nil {
+				return nil, err
+			}
+			res = addCoefficients(res, coeffs, constInterval.subConst)
+		}
+		return res, nil
+	case mul:
+		res := []constInterval{o.k}
+		for _, operand := range o.operands {
+			coeffs, err := coefficientsOf(operand, name)
+			if err != nil {
+				return nil, err
+			}
+			res = mulCoefficients(res, coeffs)
+		}
+		for _, operand := range o.invOperands {
+			coeffs, err := coefficientsOf(operand, name)
+			if err != nil {
+				return nil, err
+			}
+			if coeffs = trim(coeffs); len(coeffs) > 1 {
+				return nil, ErrNotPolynomial
+			}
+			for j := range res {
+				res[j] = res[j].divConst(coeffs[0])
+			}
+		}
+		return res, nil
+	case pow:
+		if v, ok := o.base.(variable); ok && v.varName == name {
+			//monomial x^n
+			res := make([]constInterval, o.n+1)
+			for j := 0; j < o.n; j++ {
+				res[j] = add{}.neutral()
+			}
+			res[o.n] = mul{}.neutral()
+			return res, nil
+		}
+		base, err := coefficientsOf(o.base, name)
+		if err != nil {
+			return nil, err
+		}
+		return powCoefficients(base, o.n), nil
+	}
+	return nil, ErrNotPolynomial
+}
+
+//powCoefficients returns coefficients of polynomial a raised to non-negative power n using exponentiation by squaring
+func powCoefficients(a []constInterval, n int) []constInterval {
+	res := []constInterval{mul{}.neutral()}
+	for ; n > 0; n /= 2 {
+		if n%2 == 1 {
+			res = mulCoefficients(res, a)
+		}
+		if n > 1 {
+			a = mulCoefficients(a, a)
+		}
+	}
+	return res
+}
+
+//addCoefficients applies f to coefficients of the same degree, missing coefficients are [0, 0]
+func addCoefficients(a, b []constInterval, f func(a, b constInterval) constInterval) []constInterval {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	res := make([]constInterval, n)
+	for j := range res {
+		x, y := add{}.neutral(), add{}.neutral()
+		if j < len(a) {
+			x = a[j]
+		}
+		if j < len(b) {
+			y = b[j]
+		}
+		res[j] = f(x, y)
+	}
+	return res
+}
+
+func mulCoefficients(a, b []constInterval) []constInterval {
+	res := make([]constInterval, len(a)+len(b)-1)
+	for j := range res {
+		res[j] = add{}.neutral()
+	}
+	for j, x := range a {
+		for k, y := range b {
+			res[j+k] = res[j+k].addConst(x.mulConst(y))
+		}
+	}
+	return res
+}
+
+//trim removes leading [0, 0] coefficients, polynomial always keeps at least one coefficient
+func trim(coeffs []constInterval) []constInterval {
+	for len(coeffs) > 1 && coeffs[len(coeffs)-1].isZero() {
+		coeffs = coeffs[:len(coeffs)-1]
+	}
+	return coeffs
+}
+
+//Var returns name of variable of polynomial, which is empty for polynomial without variable
+func (p Polynomial) Var() string {
+	return p.varName
+}
+
+//Degree returns degree of polynomial, it is 0 for constant polynomial
+func (p Polynomial) Degree() int {
+	return len(p.coefficients()) - 1
+}
+
+//coefficients returns coefficients of polynomial, which are [[0, 0]] for zero Polynomial
+func (p Polynomial) coefficients() []constInterval {
+	if len(p.coeffs) == 0 {
+		return []constInterval{add{}.neutral()}
+	}
+	return p.coeffs
+}
+
+//Coefficients returns coefficients of polynomial, i-th of them is coefficient of x^i
+func (p Polynomial) Coefficients() []Interval {
+	var res []Interval
+	for _, c := range p.coefficients() {
+		res = append(res, Interval{op: c})
+	}
+	return res
+}
+
+//Interval returns polynomial as expression c0 + c1 * x + c2 * x^2 + ...
+func (p Polynomial) Interval() Interval {
+	coeffs := p.coefficients()
+	res := add{m: coeffs[0]}
+	x := variable{varName: p.varName}
+	for j, c := range coeffs[1:] {
+		if c.isZero() {
+			continue
+		}
+		res.operands = append(res.operands, mul{k: c, operands: []operation{Interval{op: x}.Pow(j + 1).op}})
+	}
+	if len(res.operands) == 0 {
+		return Interval{op: res.m}
+	}
+	return Interval{op: res}
+}
+
+//String returns string representation of polynomial
+func (p Polynomial) String() string {
+	return p.Interval().String()
+}
+
+//Horner returns range of polynomial on constant domain computed with Horner scheme
+//c0 + x * (c1 + x * (c2 + ...)), which is usually tighter than evaluation of expression.
+//Returns error if domain still contains variables
+func (p Polynomial) Horner(domain Interval) (Interval, error) {
+	x, ok := domain.op.(constInterval)
+	if !ok {
+		return Interval{}, ErrNotConst
+	}
+	coeffs := p.coefficients()
+	res := coeffs[len(coeffs)-1]
+	for j := len(coeffs) - 2; j >= 0; j-- {
+		res = res.mulConst(x).addConst(coeffs[j])
+	}
+	return Interval{op: res}, nil
+}
+
+//Bernstein returns range of polynomial on constant domain computed with Bernstein-basis bounds:
+//polynomial is expanded in Bernstein basis of domain and range is hull of its coefficients.
+//Bounds at ends of domain are exact values of polynomial. Unbounded domain is evaluated with Horner.
+//Returns error if domain still contains variables
+func (p Polynomial) Bernstein(domain Interval) (Interval, error) {
+	x, ok := domain.op.(constInterval)
+	if !ok {
+		return Interval{}, ErrNotConst
+	}
+	coeffs := p.coefficients()
+	if x.absorbing() || !x.isBounded() || len(coeffs) == 1 {
+		return p.Horner(domain)
+	}
+	n := len(coeffs) - 1
+	//q(t) = p(a + (b - a) * t) has coefficients q[j] = sum of c[i] * C(i, j) * a^(i - j) * (b - a)^j for i >= j
+	a, w := x.left, new(Value).sub(x.right, x.left)
+	q := make([]constInterval, n+1)
+	for j := range q {
+		q[j] = add{}.neutral()
+		for i := j; i <= n; i++ {
+			k := new(Value).mul(binomial(i, j), new(Value).mul(powerOf(a, i-j), powerOf(w, j)))
+			q[j] = q[j].addConst(coeffs[i].mulConst(point(k)))
+		}
+	}
+	//Bernstein coefficients are b[k] = sum of C(k, j) / C(n, j) * q[j] for j <= k
+	var res constInterval
+	for k := 0; k <= n; k++ {
+		b := add{}.neutral()
+		for j := 0; j <= k; j++ {
+			b = b.addConst(q[j].mulConst(point(new(Value).div(binomial(k, j), binomial(n, j)))))
+		}
+		if k == 0 {
+			res = b
+		} else {
+			res = res.hull(b)
+		}
+	}
+	return Interval{op: res}, nil
+}
+
+func binomial(n, k int) *Value {
+	return &Value{num: new(big.Int).Binomial(int64(n), int64(k)), denom: big.NewInt(1)}
+}
+
+//powerOf returns v^n for n >= 0
+func powerOf(v *Value, n int) *Value {
+	if n == 0 {
+		return One()
+	}
+	return powValue(v, n)
+}
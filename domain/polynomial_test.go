@@ -0,0 +1,154 @@
+package domain
+
+import "testing"
+
+func TestIntervalPolynomial(t *testing.T) {
+	var testPairs = []struct {
+		expr   string
+		res    string
+		degree int
+	}{
+		{expr: "(x + 1)^2 - 2 * x", res: "[1, 1] + x^2", degree: 2},
+		{expr: "x * (x - 1) / 2", res: "[-1 / 2, -1 / 2] * x + [1 / 2, 1 / 2] * x^2", degree: 2},
+		{expr: "x^3 - x * x * x + x", res: "x", degree: 1},
+		{expr: "[1, 2] * x + sqrt(4)", res: "[2, 2] + [1, 2] * x", degree: 1},
+		{expr: "x / (1 + 1)", res: "[1 / 2, 1 / 2] * x", degree: 1},
+		{expr: "2 + 3", res: "[5, 5]", degree: 0},
+		{expr: "(x + 1)^5 - x^5", res: "[1, 1] + [5, 5] * x + [10, 10] * x^2 + [10, 10] * x^3 + [5, 5] * x^4", degree: 4},
+		{expr: "(2 * x^2)^3", res: "[8, 8] * x^6", degree: 6},
+		{expr: "x^1000000", res: "x^1000000", degree: 1000000},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		p, err := expr.Polynomial()
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := p.String(); res != pair.res {
+			t.Errorf("In pair %d: %s should be converted to %s, got %s", i, pair.expr, pair.res, res)
+		}
+		if p.Degree() != pair.degree {
+			t.Errorf("In pair %d: degree of %s should be %d, got %d", i, pair.expr, pair.degree, p.Degree())
+		}
+	}
+}
+
+func TestIntervalPolynomialError(t *testing.T) {
+	var testPairs = []string{
+		"x * y",
+		"[1, 1] / x",
+		"x / (x + 1)",
+		"sin(x)",
+		"sqrt(x)",
+		"x^-1",
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if _, err := expr.Polynomial(); err != ErrNotPolynomial {
+			t.Errorf("In pair %d: %s should not be converted to polynomial, got error %v", i, pair, err)
+		}
+	}
+	if _, err := (Interval{}).Polynomial(); err != ErrNoExpression {
+		t.Errorf("Zero interval should not be converted to polynomial, got error %v", err)
+	}
+}
+
+func TestPolynomialRange(t *testing.T) {
+	var testPairs = []struct {
+		expr      string
+		domain    Interval
+		natural   string
+		horner    string
+		bernstein string
+	}{
+		{
+			expr:      "x * x - x",
+			domain:    NewInterval(NewInt(0), NewInt(1)),
+			natural:   "[-1, 1]",
+			horner:    "[-1, 0]",
+			bernstein: "[-1 / 2, 0]",
+		},
+		{
+			expr:      "(x - 1)^2",
+			domain:    NewInterval(NewInt(0), NewInt(2)),
+			natural:   "[0, 1]",
+			horner:    "[-3, 1]",
+			bernstein: "[-1, 1]",
+		},
+		{
+			expr:      "x^3 - 3 * x",
+			domain:    NewInterval(NewInt(2), NewInt(3)),
+			natural:   "[-1, 21]",
+			horner:    "[2, 18]",
+			bernstein: "[2, 18]",
+		},
+		{
+			expr:      "x * x + 1",
+			domain:    NewInterval(NewInt(1), Inf()),
+			natural:   "[2, Inf]",
+			horner:    "[2, Inf]",
+			bernstein: "[2, Inf]",
+		},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := expr.Solve(VarMap{"x": pair.domain}).String(); res != pair.natural {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, pair.expr, pair.natural, res)
+		}
+		p, err := expr.Polynomial()
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res, err := p.Horner(pair.domain); err != nil || res.String() != pair.horner {
+			t.Errorf("In pair %d: Horner of %s should be %s, got %s, %v", i, p, pair.horner, res, err)
+		}
+		if res, err := p.Bernstein(pair.domain); err != nil || res.String() != pair.bernstein {
+			t.Errorf("In pair %d: Bernstein of %s should be %s, got %s, %v", i, p, pair.bernstein, res, err)
+		}
+	}
+}
+
+func TestPolynomialRangeNotConst(t *testing.T) {
+	x, _ := Var("x")
+	p, err := x.Mul(x).Polynomial()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := p.Horner(x); err != ErrNotConst {
+		t.Errorf("Horner on %s should return ErrNotConst, got %v", x, err)
+	}
+	if _, err := p.Bernstein(x); err != ErrNotConst {
+		t.Errorf("Bernstein on %s should return ErrNotConst, got %v", x, err)
+	}
+}
+
+func TestPolynomialZero(t *testing.T) {
+	var p Polynomial
+	if res := p.String(); res != "[0, 0]" {
+		t.Errorf("Zero polynomial should be printed as [0, 0], got %s", res)
+	}
+	if p.Degree() != 0 || len(p.Coefficients()) != 1 || p.Var() != "" {
+		t.Errorf("Zero polynomial should have degree 0 and single coefficient, got %d, %v", p.Degree(), p.Coefficients())
+	}
+	domain := NewInterval(NewInt(1), NewInt(2))
+	if res, err := p.Horner(domain); err != nil || res.String() != "[0, 0]" {
+		t.Errorf("Horner of zero polynomial should be [0, 0], got %s, %v", res, err)
+	}
+	if res, err := p.Bernstein(domain); err != nil || res.String() != "[0, 0]" {
+		t.Errorf("Bernstein of zero polynomial should be [0, 0], got %s, %v", res, err)
+	}
+}
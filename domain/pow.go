@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"math/big"
+	"strconv"
+)
+
+//maxPower is the largest absolute value of integer power and integer in parsed expression.
+//Exact powers grow with n, so n is limited like decimal exponents accepted by ParseValue
+const maxPower = 1000000
+
+//Pow returns current interval raised to integer power n.
+//Unlike product of n equal intervals power is tight: [-1, 2].Pow(2) = [0, 4], not [-2, 4].
+//Negative power is inversion of positive one: x.Pow(-n) = [1, 1] / x.Pow(n), x.Pow(0) = [1, 1].
+//Power with |n| greater than 1000000 is undefined interval
+func (i Interval) Pow(n int) Interval {
+	if n > maxPower || n < -maxPower {
+		return Interval{op: undefinedInterval()}
+	}
+	if n < 0 {
+		op := mul{
+			k:        mul{}.neutral(),
+			operands: []operation{i.Pow(-n).op},
+		}
+		i.op = op.inversed().op()
+		return i
+	}
+	if n == 1 {
+		return i
+	}
+	i.op = pow{base: i.op, n: n}
+	return i
+}
+
+//pow is base raised to non-negative integer power n
+type pow struct {
+	base operation
+	n    int
+}
+
+func (o pow) priority() byte {
+	return 3
+}
+
+func (o pow) children() []operation {
+	return []operation{o.base}
+}
+
+func (o pow) withChildren(children []operation) operation {
+	o.base = children[0]
+	return o
+}
+
+func (o pow) Solve(varMap VarMap) operation {
+	switch base := o.base.Solve(varMap).(type) {
+	case constInterval:
+		return base.powConst(o.n)
+	case multiInterval:
+		var pieces []constInterval
+		for _, piece := range base {
+			pieces = append(pieces, piece.powConst(o.n))
+		}
+		return newMultiInterval(pieces)
+	case pow:
+		if o.n != 0 && base.n > maxPower/o.n {
+			//product of powers is not allowed, so nested power is kept
+			return pow{base: base, n: o.n}
+		}
+		return pow{base: base.base, n: base.n * o.n}
+	default:
+		return pow{base: base, n: o.n}
+	}
+}
+
+func (o pow) String() string {
+	return wrap(o.base, o.priority(), true) + "^" + strconv.Itoa(o.n)
+}
+
+func (o pow) mul(multiplier operation) operation {
+	return mul{
+		k:        mul{}.neutral(),
+		operands: []operation{o, multiplier},
+	}
+}
+
+func (o pow) add(addend operation) operation {
+	return add{
+		m:        add{}.neutral(),
+		operands: []operation{o, addend},
+	}
+}
+
+//powConst returns {x^n | x in i}.
+//Odd powers are monotonic, even powers decrease on negative half-line and increase on positive one
+func (i constInterval) powConst(n int) constInterval {
+	if i.absorbing() {
+		return i
+	}
+	if n == 0 {
+		return mul{}.neutral()
+	}
+	left, right := powValue(i.left, n), powValue(i.right, n)
+	if n%2 == 1 || i.left.sign() >= 0 {
+		return constInterval{left, right}
+	}
+	if i.right.sign() <= 0 {
+		return constInterval{right, left}
+	}
+	return hullOf(Zero(), left, right)
+}
+
+//powValue returns v^n for n > 0
+func powValue(v *Value, n int) *Value {
+	if v.IsInf() {
+		if n%2 == 0 || v.sign() > 0 {
+			return Inf()
+		}
+		return NegInf()
+	}
+	exp := big.NewInt(int64(n))
+	return &Value{
+		num:   new(big.Int).Exp(v.num, exp, nil),
+		denom: new(big.Int).Exp(v.denom, exp, nil),
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestIntervalPow(t *testing.T) {
+	x, _ := Var("x")
+	var testPairs = []struct {
+		interval Interval
+		res      string
+	}{
+		{
+			interval: NewInterval(NewInt(-1), NewInt(2)).Pow(2),
+			res:      "[0, 4]",
+		},
+		{
+			interval: NewInterval(NewInt(-2), NewInt(3)).Pow(3),
+			res:      "[-8, 27]",
+		},
+		{
+			interval: NewInterval(NewInt(-3), NewInt(-1)).Pow(2),
+			res:      "[1, 9]",
+		},
+		{
+			interval: NewInterval(NewFrac(1, 2), NewFrac(2, 3)).Pow(2),
+			res:      "[1 / 4, 4 / 9]",
+		},
+		{
+			interval: NewInterval(NegInf(), NewInt(-2)).Pow(3),
+			res:      "[-Inf, -8]",
+		},
+		{
+			interval: NewInterval(NegInf(), NewInt(1)).Pow(4),
+			res:      "[0, Inf]",
+		},
+		{
+			interval: NewInterval(NewInt(2), NewInt(4)).Pow(-1),
+			res:      "[1 / 4, 1 / 2]",
+		},
+		{
+			interval: NewInterval(NewInt(-1), NewInt(1)).Pow(-2),
+			res:      "[1, Inf]",
+		},
+		{
+			interval: NewInterval(NewInt(-5), NewInt(7)).Pow(0),
+			res:      "[1, 1]",
+		},
+		{
+			interval: Empty().Pow(2),
+			res:      "[]",
+		},
+		{
+			interval: NewInterval(NaN(), NaN()).Pow(0),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: x.Pow(2).Pow(3),
+			res:      "x^6",
+		},
+		{
+			interval: x.Add(NewInterval(NewInt(1), NewInt(1))).Pow(2).Mul(x.Pow(-3)),
+			res:      "([1, 1] + x)^2 / x^3",
+		},
+		{
+			interval: NewInterval(NewInt(3), NewInt(3)).Pow(200000000),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: x.Pow(-1000001),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: x.Pow(1000).Pow(1001),
+			res:      "(x^1000)^1001",
+		},
+		{
+			interval: x.Pow(1000).Pow(1000),
+			res:      "x^1000000",
+		},
+	}
+	for i, pair := range testPairs {
+		if res := pair.interval.Solve(VarMap{}).String(); res != pair.res {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, pair.interval, pair.res, res)
+		}
+	}
+
+	expr := x.Pow(2).Sub(x)
+	varMap := VarMap{"x": NewInterval(NewInt(-1), NewInt(2))}
+	if res := expr.Solve(varMap).String(); res != "[-2, 5]" {
+		t.Errorf("%s should be solved to [-2, 5], got %s", expr, res)
+	}
+	set, _ := NewIntervalSet(NewInterval(NewInt(-2), NewInt(-1)), NewInterval(NewInt(1), NewInt(3)))
+	if res := x.Pow(2).Solve(VarMap{"x": set.Interval()}).String(); res != "[1, 9]" {
+		t.Errorf("x^2 should be solved to [1, 9], got %s", res)
+	}
+}
+
+func TestIntervalPowString(t *testing.T) {
+	x, _ := Var("x")
+	var testPairs = []struct {
+		interval Interval
+		res      string
+	}{
+		{x.Pow(2), "x^2"},
+		{x.Pow(2).Pow(3), "(x^2)^3"},
+		{x.Pow(-1), "[1, 1] / x"},
+		{x.Pow(1), "x"},
+		{NewInterval(NewInt(-1), NewInt(2)).Pow(2), "[-1, 2]^2"},
+		{x.Mul(x).Pow(2), "(x * x)^2"},
+	}
+	for i, pair := range testPairs {
+		if res := pair.interval.String(); res != pair.res {
+			t.Errorf("In pair %d: string should be %s, got %s", i, pair.res, res)
+		}
+	}
+}
+
+func TestIntervalPowInclusion(t *testing.T) {
+	property := func(a inclusionCase, n int8) bool {
+		power := int(n%5) + 1
+		if n < 0 {
+			power = -power
+		}
+		if power < 0 && a.point.Sign() == 0 {
+			return true
+		}
+		point := One()
+		for j := 0; j < power; j++ {
+			point.Mul(point, a.point)
+		}
+		for j := 0; j > power; j-- {
+			point.Div(point, a.point)
+		}
+		res, ok := Interval{op: a.interval}.Pow(power).Solve(VarMap{}).op.(constInterval)
+		return ok && inclusionCase{interval: res}.contains(point)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000, Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Errorf("Pow violates inclusion property: %s", err)
+	}
+}
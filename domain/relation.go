@@ -0,0 +1,152 @@
+package domain
+
+import "errors"
+
+//ErrNotConst is returned by set operations on interval which still contains variables
+var ErrNotConst = errors.New("interval contains variables, solve it first")
+
+//ErrUndefined is returned by set operations on interval with NaN bounds
+var ErrUndefined = errors.New("interval is undefined")
+
+//emptyInterval returns interval without any points.
+//It is represented with bounds [Inf, -Inf], which can not be a result of any other operation
+func emptyInterval() constInterval {
+	return constInterval{Inf(), NegInf()}
+}
+
+func (i constInterval) isEmpty() bool {
+	return i.left.cmp(Inf()) == 0 && i.right.cmp(NegInf()) == 0
+}
+
+//constants returns folded intervals i and j or error if one of them is not constant or undefined
+func constants(i, j Interval) (constInterval, constInterval, error) {
+	a, ok := i.op.(constInterval)
+	if !ok {
+		return constInterval{}, constInterval{}, ErrNotConst
+	}
+	b, ok := j.op.(constInterval)
+	if !ok {
+		return constInterval{}, constInterval{}, ErrNotConst
+	}
+	if a.isUndefined() || b.isUndefined() {
+		return constInterval{}, constInterval{}, ErrUndefined
+	}
+	return a, b, nil
+}
+
+//Contains returns true if value v lies in interval.
+//NaN is never contained in interval
+func (i Interval) Contains(v *Value) (bool, error) {
+	c, _, err := constants(i, i)
+	if err != nil {
+		return false, err
+	}
+	return c.contains(v), nil
+}
+
+//Subset returns true if every point of interval lies in j.
+//Empty interval is subset of any interval
+func (i Interval) Subset(j Interval) (bool, error) {
+	a, b, err := constants(i, j)
+	if err != nil {
+		return false, err
+	}
+	return a.subset(b), nil
+}
+
+//Interior returns true if interval lies in interior of j, i.e. is subset of j and touches none of its finite bounds.
+//Empty interval is interior to any interval
+func (i Interval) Interior(j Interval) (bool, error) {
+	a, b, err := constants(i, j)
+	if err != nil {
+		return false, err
+	}
+	if a.isEmpty() {
+		return true, nil
+	}
+	if b.isEmpty() {
+		return false, nil
+	}
+	left := b.left.cmp(a.left) < 0 || b.left.cmp(NegInf()) == 0 && a.left.cmp(NegInf()) == 0
+	right := a.right.cmp(b.right) < 0 || b.right.cmp(Inf()) == 0 && a.right.cmp(Inf()) == 0
+	return left && right, nil
+}
+
+//Intersect returns interval of points lying both in interval and j.
+//Result is empty interval if intervals are disjoint
+func (i Interval) Intersect(j Interval) (Interval, error) {
+	a, b, err := constants(i, j)
+	if err != nil {
+		return Interval{}, err
+	}
+	return Interval{op: a.intersect(b)}, nil
+}
+
+//Hull returns the smallest interval containing both interval and j
+func (i Interval) Hull(j Interval) (Interval, error) {
+	a, b, err := constants(i, j)
+	if err != nil {
+		return Interval{}, err
+	}
+	return Interval{op: a.hull(b)}, nil
+}
+
+//Disjoint returns true if interval and j have no common points.
+//Empty interval is disjoint with any interval
+func (i Interval) Disjoint(j Interval) (bool, error) {
+	a, b, err := constants(i, j)
+	if err != nil {
+		return false, err
+	}
+	return a.intersect(b).isEmpty(), nil
+}
+
+//Overlaps returns true if interval and j have at least one common point
+func (i Interval) Overlaps(j Interval) (bool, error) {
+	disjoint, err := i.Disjoint(j)
+	return !disjoint && err == nil, err
+}
+
+func (i constInterval) contains(v *Value) bool {
+	if v.IsNaN() || i.isEmpty() || i.isUndefined() {
+		return false
+	}
+	return i.left.cmp(v) <= 0 && v.cmp(i.right) <= 0
+}
+
+func (a constInterval) subset(b constInterval) bool {
+	if a.isEmpty() {
+		return true
+	}
+	if b.isEmpty() {
+		return false
+	}
+	return b.left.cmp(a.left) <= 0 && a.right.cmp(b.right) <= 0
+}
+
+func (a constInterval) intersect(b constInterval) constInterval {
+	if a.isEmpty() || b.isEmpty() {
+		return emptyInterval()
+	}
+	res := constInterval{a.left, a.right}
+	if b.left.cmp(res.left) > 0 {
+		res.left = b.left
+	}
+	if b.right.cmp(res.right) < 0 {
+		res.right = b.right
+	}
+	if res.left.cmp(res.right) > 0 {
+		return emptyInterval()
+	}
+	return res
+}
+
+func (a constInterval) hull(b constInterval) constInterval {
+	if a.isEmpty() {
+		return b
+	}
+	if b.isEmpty() {
+		return a
+	}
+	return hullOf(a.left, a.right, b.left, b.right)
+}
@@ -0,0 +1,131 @@
+package domain
+
+import "testing"
+
+func TestIntervalRelations(t *testing.T) {
+	interval := func(a, b, c, d int64) Interval {
+		return NewInterval(NewFrac(a, b), NewFrac(c, d))
+	}
+	var testPairs = []struct {
+		a         Interval
+		b         Interval
+		subset    bool
+		interior  bool
+		disjoint  bool
+		intersect string
+		hull      string
+	}{
+		{
+			a:         interval(1, 1, 2, 1),
+			b:         interval(0, 1, 3, 1),
+			subset:    true,
+			interior:  true,
+			intersect: "[1, 2]",
+			hull:      "[0, 3]",
+		},
+		{
+			a:         interval(0, 1, 2, 1),
+			b:         interval(0, 1, 3, 1),
+			subset:    true,
+			intersect: "[0, 2]",
+			hull:      "[0, 3]",
+		},
+		{
+			a:         interval(-1, 2, 2, 1),
+			b:         interval(1, 1, 3, 1),
+			intersect: "[1, 2]",
+			hull:      "[-1 / 2, 3]",
+		},
+		{
+			a:         interval(-1, 2, 1, 2),
+			b:         interval(1, 1, 3, 1),
+			disjoint:  true,
+			intersect: "[]",
+			hull:      "[-1 / 2, 3]",
+		},
+		{
+			a:         interval(-1, 2, 1, 1),
+			b:         interval(1, 1, 3, 1),
+			intersect: "[1, 1]",
+			hull:      "[-1 / 2, 3]",
+		},
+		{
+			a:         interval(1, 1, 2, 1),
+			b:         NewInterval(NegInf(), Inf()),
+			subset:    true,
+			interior:  true,
+			intersect: "[1, 2]",
+			hull:      "[-Inf, Inf]",
+		},
+		{
+			a:         NewInterval(NegInf(), One()),
+			b:         NewInterval(NegInf(), Inf()),
+			subset:    true,
+			interior:  true,
+			intersect: "[-Inf, 1]",
+			hull:      "[-Inf, Inf]",
+		},
+		{
+			a:         Interval{op: emptyInterval()},
+			b:         interval(1, 1, 3, 1),
+			subset:    true,
+			interior:  true,
+			disjoint:  true,
+			intersect: "[]",
+			hull:      "[1, 3]",
+		},
+	}
+	for i, pair := range testPairs {
+		if subset, err := pair.a.Subset(pair.b); err != nil || subset != pair.subset {
+			t.Errorf("In pair %d: %s subset of %s should be %t", i, pair.a, pair.b, pair.subset)
+		}
+		if interior, err := pair.a.Interior(pair.b); err != nil || interior != pair.interior {
+			t.Errorf("In pair %d: %s interior to %s should be %t", i, pair.a, pair.b, pair.interior)
+		}
+		if disjoint, err := pair.a.Disjoint(pair.b); err != nil || disjoint != pair.disjoint {
+			t.Errorf("In pair %d: %s disjoint with %s should be %t", i, pair.a, pair.b, pair.disjoint)
+		}
+		if overlaps, err := pair.a.Overlaps(pair.b); err != nil || overlaps == pair.disjoint {
+			t.Errorf("In pair %d: %s overlaps with %s should be %t", i, pair.a, pair.b, !pair.disjoint)
+		}
+		if res, err := pair.a.Intersect(pair.b); err != nil || res.String() != pair.intersect {
+			t.Errorf("In pair %d: intersection of %s and %s should be %s, got %s", i, pair.a, pair.b, pair.intersect, res)
+		}
+		if res, err := pair.a.Hull(pair.b); err != nil || res.String() != pair.hull {
+			t.Errorf("In pair %d: hull of %s and %s should be %s, got %s", i, pair.a, pair.b, pair.hull, res)
+		}
+	}
+}
+
+func TestIntervalContains(t *testing.T) {
+	interval := NewInterval(NewFrac(-1, 2), NewFrac(3, 1))
+	var testPairs = []struct {
+		v   *Value
+		res bool
+	}{
+		{v: NewFrac(-1, 2), res: true},
+		{v: NewFrac(1, 1), res: true},
+		{v: NewFrac(3, 1), res: true},
+		{v: NewFrac(-2, 3), res: false},
+		{v: Inf(), res: false},
+		{v: NaN(), res: false},
+	}
+	for i, pair := range testPairs {
+		if res, err := interval.Contains(pair.v); err != nil || res != pair.res {
+			t.Errorf("In pair %d: %s contains %s should be %t", i, interval, pair.v, pair.res)
+		}
+	}
+}
+
+func TestIntervalRelationsNotConst(t *testing.T) {
+	x, _ := Var("x")
+	if _, err := x.Contains(One()); err != ErrNotConst {
+		t.Errorf("Contains on %s should fail with %v, got %v", x, ErrNotConst, err)
+	}
+	if _, err := NewInterval(One(), One()).Intersect(x); err != ErrNotConst {
+		t.Errorf("Intersect with %s should fail with %v, got %v", x, ErrNotConst, err)
+	}
+	if _, err := NewInterval(NaN(), One()).Hull(NewInterval(One(), One())); err != ErrUndefined {
+		t.Errorf("Hull of undefined interval should fail with %v, got %v", ErrUndefined, err)
+	}
+}
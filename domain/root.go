@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"math/big"
+	"strconv"
+)
+
+//roundingDenominator is default denominator of bounds of results which can not be represented exactly as fractions,
+//like square roots. Such bounds are rounded outward to multiple of 1 / 2^32,
+//so result always contains the exact one
+const roundingDenominator = 1 << 32
+
+//maxRootDegree is the largest absolute value of root degree.
+//Inexact root is computed with integers of n * 32 bits, so it is limited much stronger than maxPower
+const maxRootDegree = 1000
+
+//Sqrt returns square root of current interval. Negative part of interval is clipped,
+//so [-4, 9].Sqrt() = [0, 3] and square root of negative interval is empty
+func (i Interval) Sqrt() Interval {
+	return i.Root(2)
+}
+
+//SqrtPrec returns square root of current interval like Sqrt,
+//but inexact bounds are rounded outward to multiple of 1 / denom
+func (i Interval) SqrtPrec(denom int64) Interval {
+	return i.RootPrec(2, denom)
+}
+
+//Root returns nth root of current interval.
+//Bounds of result are exact if they are roots of fractions, else they are rounded outward
+//to multiple of 1 / 2^32. Negative part of interval is clipped for even n,
+//odd roots are defined on whole line. Negative n means inversion: x.Root(-n) = [1, 1] / x.Root(n),
+//root with n = 0 or |n| greater than 1000 is undefined interval
+func (i Interval) Root(n int) Interval {
+	return i.RootPrec(n, roundingDenominator)
+}
+
+//RootPrec returns nth root of current interval like Root,
+//but inexact bounds are rounded outward to multiple of 1 / denom. Root with non-positive denom is undefined interval
+func (i Interval) RootPrec(n int, denom int64) Interval {
+	switch {
+	case n == 0 || n > maxRootDegree || n < -maxRootDegree || denom <= 0:
+		return Interval{op: undefinedInterval()}
+	case n < 0:
+		op := mul{
+			k:        mul{}.neutral(),
+			operands: []operation{i.RootPrec(-n, denom).op},
+		}
+		i.op = op.inversed().op()
+		return i
+	case n == 1:
+		return i
+	}
+	i.op = root{arg: i.op, n: n, denom: denom}
+	return i
+}
+
+//root is nth root of arg, n is at least 2. Inexact bounds are rounded outward to multiple of 1 / denom
+type root struct {
+	arg   operation
+	n     int
+	denom int64
+}
+
+func (o root) priority() byte {
+	return 255
+}
+
+func (o root) children() []operation {
+	return []operation{o.arg}
+}
+
+func (o root) withChildren(children []operation) operation {
+	o.arg = children[0]
+	return o
+}
+
+func (o root) Solve(varMap VarMap) operation {
+	switch arg := o.arg.Solve(varMap).(type) {
+	case constInterval:
+		return arg.rootConst(o.n, o.denom)
+	case multiInterval:
+		var pieces []constInterval
+		for _, piece := range arg {
+			pieces = append(pieces, piece.rootConst(o.n, o.denom))
+		}
+		return newMultiInterval(pieces)
+	default:
+		return root{arg: arg, n: o.n, denom: o.denom}
+	}
+}
+
+func (o root) String() string {
+	switch {
+	case o.denom != roundingDenominator:
+		return "root(" + o.arg.String() + ", " + strconv.Itoa(o.n) + ", " + strconv.FormatInt(o.denom, 10) + ")"
+	case o.n == 2:
+		return "sqrt(" + o.arg.String() + ")"
+	}
+	return "root(" + o.arg.String() + ", " + strconv.Itoa(o.n) + ")"
+}
+
+func (o root) mul(multiplier operation) operation {
+	return mul{
+		k:        mul{}.neutral(),
+		operands: []operation{o, multiplier},
+	}
+}
+
+func (o root) add(addend operation) operation {
+	return add{
+		m:        add{}.neutral(),
+		operands: []operation{o, addend},
+	}
+}
+
+//rootConst returns interval containing {x^(1/n) | x in i} with inexact bounds rounded outward
+//to multiple of 1 / denom. Negative part of i is clipped if n is even
+func (i constInterval) rootConst(n int, denom int64) constInterval {
+	if i.absorbing() {
+		return i
+	}
+	left := i.left
+	if n%2 == 0 {
+		if i.right.sign() < 0 {
+			return emptyInterval()
+		}
+		if left.sign() < 0 {
+			left = Zero()
+		}
+	}
+	return constInterval{
+		left:  rootValue(left, n, denom, RoundDown),
+		right: rootValue(i.right, n, denom, RoundUp),
+	}
+}
+
+//rootValue returns v^(1/n) if it is fraction, else it is rounded in direction mode
+//to multiple of 1 / denom. v should be non-negative if n is even
+func rootValue(v *Value, n int, denom int64, mode RoundingMode) *Value {
+	if v.IsInf() || v.sign() == 0 {
+		return new(Value).set(v)
+	}
+	if v.sign() < 0 {
+		opposite := RoundUp
+		if mode == RoundUp {
+			opposite = RoundDown
+		}
+		return new(Value).Neg(rootValue(new(Value).Neg(v), n, denom, opposite))
+	}
+	numRoot, numExact := intRoot(v.num, n)
+	denomRoot, denomExact := intRoot(v.denom, n)
+	if numExact && denomExact {
+		return &Value{num: numRoot, denom: denomRoot}
+	}
+	d := big.NewInt(denom)
+	scaled := new(big.Int).Mul(v.num, new(big.Int).Exp(d, big.NewInt(int64(n)), nil))
+	scaled = divRound(scaled, v.denom, mode)
+	res, exact := intRoot(scaled, n)
+	if !exact && mode == RoundUp {
+		res.Add(res, big.NewInt(1))
+	}
+	return (&Value{num: res, denom: d}).reduce()
+}
+
+//intRoot returns floor of nth root of non-negative x and reports whether it is exact
+func intRoot(x *big.Int, n int) (*big.Int, bool) {
+	if x.Sign() == 0 {
+		return new(big.Int), true
+	}
+	var res *big.Int
+	if n == 2 {
+		res = new(big.Int).Sqrt(x)
+	} else {
+		//Newton's method starting from value greater than root, which decreases until root is reached
+		res = new(big.Int).Lsh(big.NewInt(1), uint(x.BitLen()/n+1))
+		bigN, bigN1 := big.NewInt(int64(n)), big.NewInt(int64(n-1))
+		for {
+			next := new(big.Int).Quo(x, new(big.Int).Exp(res, bigN1, nil))
+			next.Add(next, new(big.Int).Mul(bigN1, res))
+			next.Quo(next, bigN)
+			if next.Cmp(res) >= 0 {
+				break
+			}
+			res = next
+		}
+	}
+	return res, new(big.Int).Exp(res, big.NewInt(int64(n)), nil).Cmp(x) == 0
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestIntervalRoot(t *testing.T) {
+	x, _ := Var("x")
+	var testPairs = []struct {
+		interval Interval
+		res      string
+	}{
+		{
+			interval: NewInterval(NewInt(4), NewInt(9)).Sqrt(),
+			res:      "[2, 3]",
+		},
+		{
+			interval: NewInterval(NewFrac(1, 4), NewFrac(9, 16)).Sqrt(),
+			res:      "[1 / 2, 3 / 4]",
+		},
+		{
+			interval: NewInterval(NewInt(-4), NewInt(9)).Sqrt(),
+			res:      "[0, 3]",
+		},
+		{
+			interval: NewInterval(NewInt(-9), NewInt(-4)).Sqrt(),
+			res:      "[]",
+		},
+		{
+			interval: NewInterval(NewInt(-27), NewInt(8)).Root(3),
+			res:      "[-3, 2]",
+		},
+		{
+			interval: NewInterval(NewInt(16), Inf()).Root(4),
+			res:      "[2, Inf]",
+		},
+		{
+			interval: NewInterval(NewInt(3), NewInt(3)).Root(1001),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: NewInterval(NewInt(4), NewInt(9)).Root(-2),
+			res:      "[1 / 3, 1 / 2]",
+		},
+		{
+			interval: NewInterval(NewInt(4), NewInt(9)).Root(0),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: NewInterval(NewInt(4), NewInt(9)).RootPrec(2, 0),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: NewInterval(NewInt(2), NewInt(8)).RootPrec(-2, 10),
+			res:      "[10 / 29, 5 / 7]",
+		},
+		{
+			interval: x.RootPrec(3, 1000),
+			res:      "root(x, 3, 1000)",
+		},
+		{
+			interval: NewInterval(NaN(), NaN()).Sqrt(),
+			res:      "[NaN, NaN]",
+		},
+		{
+			interval: x.Sqrt().Add(x),
+			res:      "sqrt(x) + x",
+		},
+	}
+	for i, pair := range testPairs {
+		if res := pair.interval.Solve(VarMap{}).String(); res != pair.res {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, pair.interval, pair.res, res)
+		}
+	}
+	set, _ := NewIntervalSet(NewInterval(NewInt(-8), NewInt(-1)), NewInterval(NewInt(1), NewInt(4)))
+	if res := x.Sqrt().Solve(VarMap{"x": set.Interval()}).String(); res != "[1, 2]" {
+		t.Errorf("sqrt(x) should be solved to [1, 2], got %s", res)
+	}
+}
+
+func TestIntervalSqrtRounding(t *testing.T) {
+	res := NewInterval(NewInt(2), NewInt(3)).SqrtPrec(1000).Solve(VarMap{})
+	if res.String() != "[707 / 500, 1733 / 1000]" {
+		t.Errorf("sqrt([2, 3]) should be [707 / 500, 1733 / 1000], got %s", res)
+	}
+	res = NewInterval(NewInt(-3), NewInt(-2)).RootPrec(3, 1000).Solve(VarMap{})
+	if res.String() != "[-1443 / 1000, -1259 / 1000]" {
+		t.Errorf("root([-3, -2], 3) should be [-1443 / 1000, -1259 / 1000], got %s", res)
+	}
+	res = NewInterval(NewInt(2), NewInt(3)).Sqrt().Solve(VarMap{})
+	if res.String() != "[6074000999 / 4294967296, 3719550787 / 2147483648]" {
+		t.Errorf("sqrt([2, 3]) should be [6074000999 / 4294967296, 3719550787 / 2147483648], got %s", res)
+	}
+	res = NewInterval(NewInt(-3), NewInt(-2)).Root(3).Solve(VarMap{})
+	if res.String() != "[-3097207369 / 2147483648, -676414963 / 536870912]" {
+		t.Errorf("root([-3, -2], 3) should be [-3097207369 / 2147483648, -676414963 / 536870912], got %s", res)
+	}
+}
+
+func TestIntRoot(t *testing.T) {
+	var testPairs = []struct {
+		x     int64
+		n     int
+		root  int64
+		exact bool
+	}{
+		{0, 3, 0, true},
+		{1, 5, 1, true},
+		{26, 3, 2, false},
+		{27, 3, 3, true},
+		{28, 3, 3, false},
+		{1 << 40, 4, 1 << 10, true},
+		{1<<40 - 1, 4, 1<<10 - 1, false},
+		{99, 2, 9, false},
+	}
+	for i, pair := range testPairs {
+		root, exact := intRoot(big.NewInt(pair.x), pair.n)
+		if root.Int64() != pair.root || exact != pair.exact {
+			t.Errorf("In pair %d: root of %d should be %d, %v, got %s, %v", i, pair.x, pair.root, pair.exact, root, exact)
+		}
+	}
+}
+
+func TestIntervalRootInclusion(t *testing.T) {
+	property := func(a inclusionCase, n uint8) bool {
+		power := int(n%4) + 2
+		res, ok := Interval{op: a.interval}.Root(power).Solve(VarMap{}).op.(constInterval)
+		if !ok {
+			return false
+		}
+		if power%2 == 0 && a.point.Sign() < 0 {
+			return true
+		}
+		//root is monotonic, so root of point lies in result if point lies between powers of its bounds
+		return !res.isEmpty() && powValue(res.left, power).cmp(a.point) <= 0 && powValue(res.right, power).cmp(a.point) >= 0
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000, Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Errorf("Root violates inclusion property: %s", err)
+	}
+}
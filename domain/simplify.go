@@ -0,0 +1,218 @@
+package domain
+
+import "sort"
+
+//Simplify returns equivalent interval with reduced dependency problem.
+//Like terms of sums are collected, so x + [2, 2] * x - x becomes [2, 2] * x and x - x becomes [0, 0],
+//equal factors of products are combined into powers, so x * x / y * y becomes x^2 and x / x becomes [1, 1].
+//Only subexpressions without non-point constant intervals are collected, because every occurrence
+//of such interval means independent value. Factors are not combined if power is out of range allowed by Pow.
+//Constants are folded like in Solve, zero Interval is returned unchanged
+func (i Interval) Simplify() Interval {
+	if i.op == nil {
+		return i
+	}
+	i.op = simplify(i.op)
+	return i
+}
+
+func simplify(op operation) operation {
+	if children := op.children(); len(children) != 0 {
+		simplified := make([]operation, len(children))
+		for j, child := range children {
+			simplified[j] = simplify(child)
+		}
+		op = op.withChildren(simplified)
+	}
+	switch o := op.Solve(VarMap{}).(type) {
+	case add:
+		return o.collect().Solve(VarMap{})
+	case mul:
+		return o.collect().Solve(VarMap{})
+	default:
+		return o
+	}
+}
+
+//term is subexpression of sum or product with its rational multiplier or integer power
+type term struct {
+	op operation
+	k  *Value
+}
+
+//terms collects terms with equal keys summing their multipliers and keeps order of their first occurrence
+type terms struct {
+	list  []*term
+	index map[string]*term
+}
+
+//add adds k to multiplier of op and returns its term
+func (t *terms) add(op operation, k *Value) *term {
+	if !pointwise(op) {
+		t.list = append(t.list, &term{op: op, k: k})
+		return t.list[len(t.list)-1]
+	}
+	key := canonical(op).String()
+	if existing, ok := t.index[key]; ok {
+		existing.k = new(Value).add(existing.k, k)
+		return existing
+	}
+	if t.index == nil {
+		t.index = map[string]*term{}
+	}
+	t.index[key] = &term{op: op, k: k}
+	t.list = append(t.list, t.index[key])
+	return t.index[key]
+}
+
+//collect collects like terms of sum: multipliers of equal terms are summed
+func (o add) collect() operation {
+	var t terms
+	for _, operand := range o.operands {
+		op, k := splitMultiplier(operand)
+		t.add(op, k)
+	}
+	for _, operand := range o.invOperands {
+		op, k := splitMultiplier(operand)
+		t.add(op, k.Neg(k))
+	}
+	res := add{m: o.m}
+	for _, term := range t.list {
+		switch {
+		case term.k.sign() == 0:
+		case term.k.cmp(One()) == 0:
+			res.operands = append(res.operands, term.op)
+		case term.k.cmp(NewInt(-1)) == 0:
+			res.invOperands = append(res.invOperands, term.op)
+		case term.k.sign() > 0:
+			res.operands = append(res.operands, mul{k: point(term.k), operands: []operation{term.op}})
+		default:
+			k := new(Value).Neg(term.k)
+			res.invOperands = append(res.invOperands, mul{k: point(k), operands: []operation{term.op}})
+		}
+	}
+	if res.m.isZero() && len(res.operands) == 1 && len(res.invOperands) == 0 {
+		return res.operands[0]
+	}
+	return res
+}
+
+//splitMultiplier splits operand of sum into point multiplier and the rest of it
+func splitMultiplier(op operation) (operation, *Value) {
+	m, ok := op.(mul)
+	if !ok || m.k.isUndefined() || !m.k.isBounded() || m.k.left.cmp(m.k.right) != 0 {
+		return op, One()
+	}
+	k := new(Value).set(m.k.left)
+	if len(m.operands) == 1 && len(m.invOperands) == 0 {
+		return m.operands[0], k
+	}
+	m.k = m.neutral()
+	return m, k
+}
+
+//collect combines equal factors of product into powers.
+//Factors are kept uncombined if their power is greater than maxPower by absolute value
+func (o mul) collect() operation {
+	var t terms
+	//factors of every term as they occur in product
+	factors := map[*term]*mul{}
+	factorsOf := func(term *term) *mul {
+		if factors[term] == nil {
+			factors[term] = &mul{}
+		}
+		return factors[term]
+	}
+	for _, operand := range o.operands {
+		base, n := splitPower(operand)
+		f := factorsOf(t.add(base, NewInt(int64(n))))
+		f.operands = append(f.operands, operand)
+	}
+	for _, operand := range o.invOperands {
+		base, n := splitPower(operand)
+		f := factorsOf(t.add(base, NewInt(int64(-n))))
+		f.invOperands = append(f.invOperands, operand)
+	}
+	res := mul{k: o.k}
+	for _, term := range t.list {
+		if term.k.cmp(NewInt(maxPower)) > 0 || term.k.cmp(NewInt(-maxPower)) < 0 {
+			res.operands = append(res.operands, factors[term].operands...)
+			res.invOperands = append(res.invOperands, factors[term].invOperands...)
+			continue
+		}
+		n := int(term.k.num.Int64())
+		switch {
+		case n > 0:
+			res.operands = append(res.operands, Interval{op: term.op}.Pow(n).op)
+		case n < 0:
+			res.invOperands = append(res.invOperands, Interval{op: term.op}.Pow(-n).op)
+		}
+	}
+	if res.k.equal(res.neutral()) && len(res.operands) == 1 && len(res.invOperands) == 0 {
+		return res.operands[0]
+	}
+	return res
+}
+
+//splitPower splits factor of product into base and integer power
+func splitPower(op operation) (operation, int) {
+	if p, ok := op.(pow); ok {
+		return p.base, p.n
+	}
+	return op, 1
+}
+
+//canonical returns op with sorted operands of sums and products, so equal expressions have equal strings
+func canonical(op operation) operation {
+	children := op.children()
+	if len(children) == 0 {
+		return op
+	}
+	sorted := make([]operation, len(children))
+	for j, child := range children {
+		sorted[j] = canonical(child)
+	}
+	switch o := op.withChildren(sorted).(type) {
+	case add:
+		sortOperations(o.operands)
+		sortOperations(o.invOperands)
+		return o
+	case mul:
+		sortOperations(o.operands)
+		sortOperations(o.invOperands)
+		return o
+	default:
+		return o
+	}
+}
+
+func sortOperations(ops []operation) {
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].String() < ops[j].String()
+	})
+}
+
+//pointwise returns true if op contains no non-point constant intervals and unions of intervals,
+//so all its occurrences have equal values for equal values of variables
+func pointwise(op operation) bool {
+	switch o := op.(type) {
+	case constInterval:
+		return !o.absorbing() && o.left.cmp(o.right) == 0
+	case multiInterval:
+		return false
+	case add:
+		if !pointwise(o.m) {
+			return false
+		}
+	case mul:
+		if !pointwise(o.k) {
+			return false
+		}
+	}
+	for _, child := range op.children() {
+		if !pointwise(child) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestIntervalSimplify(t *testing.T) {
+	var testPairs = []struct {
+		expr string
+		res  string
+	}{
+		{expr: "x - x", res: "[0, 0]"},
+		{expr: "x / x", res: "[1, 1]"},
+		{expr: "x + 2 * x - x", res: "[2, 2] * x"},
+		{expr: "x * x * x / y * y", res: "x^3"},
+		{expr: "x * x / (x * x * x)", res: "[1, 1] / x"},
+		{expr: "x^2 * x / x^5", res: "[1, 1] / x^2"},
+		{expr: "x * y + 3 * y * x - y * x", res: "[3, 3] * x * y"},
+		{expr: "sin(x) + 1 - sin(x)", res: "[1, 1]"},
+		{expr: "x - 2 * x - x", res: "[0, 0] - [2, 2] * x"},
+		{expr: "[1, 2] * x - [1, 2] * x", res: "[1, 2] * x - [1, 2] * x"},
+		{expr: "(x + [1, 2]) / (x + [1, 2])", res: "([1, 2] + x) / ([1, 2] + x)"},
+		{expr: "(x + 1) / (1 + x)", res: "[1, 1]"},
+		{expr: "sin(x * y) - sin(y * x)", res: "[0, 0]"},
+		{expr: "(x + 1) / (x + 1)", res: "[1, 1]"},
+		{expr: "exp(x - x) + y / y", res: "[2, 2]"},
+		{expr: "max(x * x / x, y)", res: "max(x, y)"},
+		{expr: "x^1000000 * y * x * y", res: "x^1000000 * x * y^2"},
+		{expr: "[1, 1] / (x^1000000 * x)", res: "[1, 1] / x^1000000 / x"},
+		{expr: "x^1000000 * x / x", res: "x^1000000"},
+	}
+	for i, pair := range testPairs {
+		expr, err := Parse(pair.expr)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if res := expr.Simplify().String(); res != pair.res {
+			t.Errorf("In pair %d: %s should be simplified to %s, got %s", i, pair.expr, pair.res, res)
+		}
+	}
+}
+
+func TestIntervalSimplifyZero(t *testing.T) {
+	if res := (Interval{}).Simplify(); res.op != nil {
+		t.Errorf("Zero interval should be simplified to itself, got %s", res)
+	}
+}
+
+func TestIntervalSimplifyDependency(t *testing.T) {
+	x, _ := Var("x")
+	expr := x.Mul(x).Sub(x.Mul(x)).Add(x.Div(x))
+	varMap := VarMap{"x": NewInterval(NewInt(1), NewInt(2))}
+	if res := expr.Solve(varMap).String(); res != "[-5 / 2, 5]" {
+		t.Errorf("%s should be solved to [-5 / 2, 5], got %s", expr, res)
+	}
+	if res := expr.Simplify().Solve(varMap).String(); res != "[1, 1]" {
+		t.Errorf("Simplified %s should be solved to [1, 1], got %s", expr, res)
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+//errNull is returned when NULL is scanned into Value or Interval
+var errNull = errors.New("cannot scan NULL, use pointer to scan nullable column")
+
+//Value implements driver.Valuer. Value is stored as text returned by MarshalText, like "35/6"
+func (v Value) Value() (driver.Value, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+//Scan implements sql.Scanner. Text columns are parsed as in ParseValue,
+//integer columns are converted exactly and float columns are converted as in NewFloatExact
+func (z *Value) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return errNull
+	case string:
+		return z.UnmarshalText([]byte(src))
+	case []byte:
+		return z.UnmarshalText(src)
+	case int64:
+		z.set(NewInt(src))
+		return nil
+	case float64:
+		z.set(NewFloatExact(src))
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into Value", src)
+}
+
+//Value implements driver.Valuer. Only constant interval can be stored,
+//it is stored as text with two bounds like "[1/2, 5/3]" or as "[]" if it is empty
+func (i Interval) Value() (driver.Value, error) {
+	c, ok := i.op.(constInterval)
+	if !ok {
+		return nil, ErrNotConst
+	}
+	if c.isEmpty() {
+		return "[]", nil
+	}
+	left, err := c.left.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.right.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return "[" + string(left) + ", " + string(right) + "]", nil
+}
+
+//Scan implements sql.Scanner. Text column is parsed with Parse and should contain constant interval
+func (i *Interval) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		return errNull
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into Interval", src)
+	}
+	res, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	if _, ok := res.op.(constInterval); !ok {
+		return ErrNotConst
+	}
+	*i = res
+	return nil
+}
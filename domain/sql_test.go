@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+//memDriver is in-memory stand-in database driver with single table.
+//Every executed statement appends its arguments as row, every query returns all rows
+type memDriver struct {
+	rows [][]driver.Value
+}
+
+type memConn struct {
+	d *memDriver
+}
+
+type memStmt struct {
+	d *memDriver
+}
+
+type memRows struct {
+	rows [][]driver.Value
+	pos  int
+}
+
+var testDriver = &memDriver{}
+
+func init() {
+	sql.Register("domainmem", testDriver)
+}
+
+func (d *memDriver) Open(name string) (driver.Conn, error) {
+	return memConn{d: d}, nil
+}
+
+func (c memConn) Prepare(query string) (driver.Stmt, error) {
+	return memStmt{d: c.d}, nil
+}
+
+func (c memConn) Close() error {
+	return nil
+}
+
+func (c memConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (s memStmt) Close() error {
+	return nil
+}
+
+func (s memStmt) NumInput() int {
+	return -1
+}
+
+func (s memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.rows = append(s.d.rows, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &memRows{rows: s.d.rows}, nil
+}
+
+func (r *memRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	columns := make([]string, len(r.rows[0]))
+	for i := range columns {
+		columns[i] = string(rune('a' + i))
+	}
+	return columns
+}
+
+func (r *memRows) Close() error {
+	return nil
+}
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	testDriver.rows = nil
+	db, err := sql.Open("domainmem", "")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return db
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	var testPairs = []struct {
+		v        *Value
+		interval Interval
+		stored   string
+	}{
+		{NewFrac(35, 6), NewInterval(NewFrac(1, 2), NewFrac(5, 3)), "[1/2, 5/3]"},
+		{NewFrac(-1, 3), NewInterval(NegInf(), NewInt(2)), "[-Inf, 2]"},
+		{NaN(), Empty(), "[]"},
+		{Inf(), NewInterval(NaN(), NaN()), "[NaN, NaN]"},
+	}
+	db := openTestDB(t)
+	defer db.Close()
+	for i, pair := range testPairs {
+		if _, err := db.Exec("INSERT", pair.v, pair.interval); err != nil {
+			t.Fatalf("In pair %d: unexpected error %v", i, err)
+		}
+		if stored := testDriver.rows[i][1]; stored != pair.stored {
+			t.Errorf("In pair %d: %s should be stored as %q, got %q", i, pair.interval, pair.stored, stored)
+		}
+	}
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	defer rows.Close()
+	for i := 0; rows.Next(); i++ {
+		var v Value
+		var interval Interval
+		if err := rows.Scan(&v, &interval); err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if v.cmp(testPairs[i].v) != 0 {
+			t.Errorf("In pair %d: value should be scanned as %s, got %s", i, testPairs[i].v, v)
+		}
+		if interval.String() != testPairs[i].interval.String() {
+			t.Errorf("In pair %d: interval should be scanned as %s, got %s", i, testPairs[i].interval, interval)
+		}
+	}
+}
+
+func TestSQLErrors(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	x, _ := Var("x")
+	if _, err := db.Exec("INSERT", x); err == nil {
+		t.Errorf("Interval with variables should not be stored")
+	}
+
+	var v Value
+	var interval Interval
+	var testPairs = []struct {
+		dest interface{ Scan(interface{}) error }
+		src  interface{}
+	}{
+		{&v, nil},
+		{&v, "abc"},
+		{&v, true},
+		{&interval, nil},
+		{&interval, int64(1)},
+		{&interval, "[1, 2] + x"},
+		{&interval, "{[1, 2], [3, 4]}"},
+		{&interval, "[1, 2"},
+	}
+	for i, pair := range testPairs {
+		if err := pair.dest.Scan(pair.src); err == nil {
+			t.Errorf("In pair %d: %v should not be scanned", i, pair.src)
+		}
+	}
+
+	var nullable *Value
+	testDriver.rows = [][]driver.Value{{nil}}
+	if err := db.QueryRow("SELECT").Scan(&nullable); err != nil || nullable != nil {
+		t.Errorf("NULL should be scanned into nil pointer, got %v, %v", nullable, err)
+	}
+	testDriver.rows = [][]driver.Value{{int64(3)}, {0.5}}
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	defer rows.Close()
+	for _, want := range []*Value{NewInt(3), NewFrac(1, 2)} {
+		rows.Next()
+		if err := rows.Scan(&v); err != nil || v.cmp(want) != 0 {
+			t.Errorf("Number should be scanned as %s, got %s, %v", want, v, err)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -23,8 +25,7 @@ func NegInf() *Value {
 }
 
 //NaN returns NaN value.
-//WARNING: comparing two NaN values will lead to panic
-//PANIC NaN.cmp(NaN())
+//NaN is unordered with any value, use Compare to detect it or TotalCmp to sort it
 func NaN() *Value {
 	return &Value{
 		num:   big.NewInt(0),
@@ -73,10 +74,18 @@ func NewFrac(a, b int64) *Value {
 	}).reduce()
 }
 
-//NewFloat returns new float value in fraction representation
+//NewFloat returns new float value in fraction representation.
+//Value is built from the shortest decimal representation of f, so NewFloat(0.1) is exactly 1 / 10.
+//math.NaN() and infinities are converted to NaN(), Inf() and NegInf()
 func NewFloat(f float64) *Value {
+	if special := newSpecialFloat(f); special != nil {
+		return special
+	}
 	fStr := strconv.FormatFloat(f, 'f', -1, 64)
-	digits := len(fStr) - 1 - strings.Index(fStr, ".")
+	digits := 0
+	if strings.Contains(fStr, ".") {
+		digits = len(fStr) - 1 - strings.Index(fStr, ".")
+	}
 	denom := big.NewInt(1)
 	ten := big.NewInt(10)
 	for i := 0; i < digits; i++ {
@@ -100,6 +109,159 @@ func NewFloat(f float64) *Value {
 	}).reduce()
 }
 
+//NewFloatExact returns new value exactly equal to binary value stored in f,
+//so NewFloatExact(0.1) is 3602879701896397 / 36028797018963968.
+//math.NaN() and infinities are converted to NaN(), Inf() and NegInf()
+func NewFloatExact(f float64) *Value {
+	if special := newSpecialFloat(f); special != nil {
+		return special
+	}
+	return newRat(new(big.Rat).SetFloat64(f))
+}
+
+//newSpecialFloat returns NaN(), Inf() or NegInf() for special float values and nil for finite ones
+func newSpecialFloat(f float64) *Value {
+	switch {
+	case math.IsNaN(f):
+		return NaN()
+	case math.IsInf(f, 1):
+		return Inf()
+	case math.IsInf(f, -1):
+		return NegInf()
+	}
+	return nil
+}
+
+//RoundingMode is direction of rounding of value which can not be represented exactly
+type RoundingMode byte
+
+const (
+	//RoundNearest rounds to the nearest representable number
+	RoundNearest RoundingMode = iota
+	//RoundDown rounds to the nearest representable number which is not greater than value
+	RoundDown
+	//RoundUp rounds to the nearest representable number which is not less than value
+	RoundUp
+)
+
+//Float64 returns float64 value nearest to v in direction mode and true if it is exactly equal to v.
+//Values out of float64 range are rounded to infinity or to the largest finite float64 depending on mode.
+//NaN(), Inf() and NegInf() are converted to math.NaN() and infinities
+func (v *Value) Float64(mode RoundingMode) (float64, bool) {
+	v.checkNil()
+
+	if v.IsNaN() {
+		return math.NaN(), false
+	}
+	if v.IsInf() {
+		return math.Inf(v.sign()), true
+	}
+	r := new(big.Rat).SetFrac(v.num, v.denom)
+	f, exact := r.Float64()
+	if exact {
+		return f, true
+	}
+	if mode == RoundDown && cmpRatFloat(r, f) < 0 {
+		f = math.Nextafter(f, math.Inf(-1))
+	}
+	if mode == RoundUp && cmpRatFloat(r, f) > 0 {
+		f = math.Nextafter(f, math.Inf(1))
+	}
+	return f, false
+}
+
+//cmpRatFloat compares rational r with float64 f, which may be infinite
+func cmpRatFloat(r *big.Rat, f float64) int {
+	if math.IsInf(f, 0) {
+		return -int(math.Copysign(1, f))
+	}
+	return r.Cmp(new(big.Rat).SetFloat64(f))
+}
+
+//newRat returns new value equal to rational r
+func newRat(r *big.Rat) *Value {
+	return &Value{
+		num:   new(big.Int).Set(r.Num()),
+		denom: new(big.Int).Set(r.Denom()),
+	}
+}
+
+//ParseValue returns value represented by string s.
+//Accepted formats are fractions like "35/6" or "35 / 6", decimals like "-0.125",
+//scientific notation like "1.5e-7", integers of any length and "Inf", "-Inf", "NaN".
+//Numerator and denominator of fraction may be decimals too, zero denominator results in Inf, -Inf or NaN like in NewFrac
+func ParseValue(s string) (*Value, error) {
+	v, ok := new(Value).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("bad value %q", s)
+	}
+	return v, nil
+}
+
+//SetString sets z to value represented by string s in one of formats accepted by ParseValue and returns z.
+//If s is malformed z is unchanged and ok is false
+func (z *Value) SetString(s string) (*Value, bool) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = strings.TrimSpace(s[1:])
+	}
+	var res *Value
+	switch s {
+	case "Inf":
+		res = Inf()
+	case "NaN":
+		res = NaN()
+	default:
+		parts := strings.Split(s, "/")
+		if len(parts) > 2 {
+			return z, false
+		}
+		var ok bool
+		if res, ok = parseDecimal(parts[0]); !ok {
+			return z, false
+		}
+		if len(parts) == 2 {
+			denom, ok := parseDecimal(parts[1])
+			if !ok {
+				return z, false
+			}
+			res.div(res, denom)
+		}
+	}
+	if negative {
+		res.Neg(res)
+	}
+	return z.set(res), true
+}
+
+//parseDecimal parses unsigned decimal number with optional fraction part and exponent
+func parseDecimal(s string) (*Value, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 || s[0] < '0' || s[0] > '9' {
+		return nil, false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789.eE+-", c) {
+			return nil, false
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, false
+	}
+	return newRat(r), true
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler, text is parsed as in ParseValue
+func (z *Value) UnmarshalText(text []byte) error {
+	if _, ok := z.SetString(string(text)); !ok {
+		return fmt.Errorf("bad value %q", text)
+	}
+	return nil
+}
+
 //NewInt returns new int value in fraction representation
 func NewInt(i int64) *Value {
 	return (&Value{
@@ -121,16 +283,28 @@ func (v *Value) sign() int {
 func (r *Value) reduce() *Value {
 	r.checkNil()
 
+	if r.denom.Sign() == 0 {
+		r.num.SetInt64(int64(r.num.Sign()))
+		return r
+	}
 	nod := nod(r.num, r.denom)
-	r.num.Div(r.num, nod)
-	r.denom.Div(r.denom, nod)
-	if r.num.Sign() < 0 && r.denom.Sign() < 0 {
+	r.num.Quo(r.num, nod)
+	r.denom.Quo(r.denom, nod)
+	if r.denom.Sign() < 0 {
 		r.num.Neg(r.num)
 		r.denom.Neg(r.denom)
 	}
 	return r
 }
 
+func (z *Value) set(x *Value) *Value {
+	x.checkNil()
+
+	z.num = new(big.Int).Set(x.num)
+	z.denom = new(big.Int).Set(x.denom)
+	return z
+}
+
 //String converts value to string
 func (r Value) String() string {
 	r.checkNil()
@@ -188,25 +362,18 @@ func (z *Value) add(a, b *Value) *Value {
 		b.num.Sign() == 0 && b.denom.Sign() == 0 ||
 		a.cmp(Inf()) == 0 && b.cmp(NegInf()) == 0 ||
 		b.cmp(Inf()) == 0 && a.cmp(NegInf()) == 0 {
-		z = NaN()
-		return z
+		return z.set(NaN())
 	}
 	if a.cmp(Inf()) == 0 || b.cmp(Inf()) == 0 {
-		z = Inf()
-		return z
+		return z.set(Inf())
 	}
 	if a.cmp(NegInf()) == 0 || b.cmp(NegInf()) == 0 {
-		z = NegInf()
-		return z
+		return z.set(NegInf())
 	}
 
-	nod := nod(a.denom, b.denom)
-	temp := new(big.Int)
-	temp.Div(b.denom, nod)
-	z.num.Mul(a.num, temp)
-	z.denom.Mul(a.denom, temp)
-	temp.Div(a.denom, nod)
-	z.num.Add(z.num, new(big.Int).Mul(temp, b.num))
+	num := new(big.Int).Mul(a.num, b.denom)
+	num.Add(num, new(big.Int).Mul(b.num, a.denom))
+	z.num, z.denom = num, new(big.Int).Mul(a.denom, b.denom)
 	return z.reduce()
 }
 
@@ -229,33 +396,24 @@ func (z *Value) sub(a, b *Value) *Value {
 		b.num.Sign() == 0 && b.denom.Sign() == 0 ||
 		a.cmp(Inf()) == 0 && b.cmp(Inf()) == 0 ||
 		b.cmp(NegInf()) == 0 && a.cmp(NegInf()) == 0 {
-		z = NaN()
-		return z
+		return z.set(NaN())
 	}
 	if a.cmp(Inf()) == 0 {
-		z = Inf()
-		return z
+		return z.set(Inf())
 	}
 	if a.cmp(NegInf()) == 0 {
-		z = NegInf()
-		return z
+		return z.set(NegInf())
 	}
 	if b.cmp(NegInf()) == 0 {
-		z = Inf()
-		return z
+		return z.set(Inf())
 	}
 	if b.cmp(Inf()) == 0 {
-		z = NegInf()
-		return z
+		return z.set(NegInf())
 	}
 
-	nod := nod(a.denom, b.denom)
-	temp := new(big.Int)
-	temp.Div(b.denom, nod)
-	z.num.Mul(a.num, temp)
-	z.denom.Mul(a.denom, temp)
-	temp.Div(a.denom, nod)
-	z.num.Sub(z.num, new(big.Int).Mul(temp, b.num))
+	num := new(big.Int).Mul(a.num, b.denom)
+	num.Sub(num, new(big.Int).Mul(b.num, a.denom))
+	z.num, z.denom = num, new(big.Int).Mul(a.denom, b.denom)
 	return z.reduce()
 }
 
@@ -266,7 +424,8 @@ func (z *Value) sub(a, b *Value) *Value {
 //Inf() * x == NegInf() for every negative x
 //NegInf() * x == NegInf() for every positive x
 //NegInf() * x == Inf() for every negative x
-//Inf() * NegInf() == NaN()
+//Inf() * NegInf() == NegInf()
+//Inf() * Zero() == NaN() and NegInf() * Zero() == NaN()
 //NaN() * x == NaN() for every x
 //NaN() * NaN() == NaN() for every x
 func (z *Value) mul(a, b *Value) *Value {
@@ -276,21 +435,20 @@ func (z *Value) mul(a, b *Value) *Value {
 
 	if a.num.Sign() == 0 && a.denom.Sign() == 0 ||
 		b.num.Sign() == 0 && b.denom.Sign() == 0 {
-		z = NaN()
-		return z
+		return z.set(NaN())
 	}
 	if a.cmp(Inf()) == 0 || b.cmp(Inf()) == 0 ||
 		a.cmp(NegInf()) == 0 || b.cmp(NegInf()) == 0 {
 		if a.sign()*b.sign() > 0 {
-			z = Inf()
-			return z
+			return z.set(Inf())
+		}
+		if a.sign()*b.sign() < 0 {
+			return z.set(NegInf())
 		}
-		z = NegInf()
-		return z
+		return z.set(NaN())
 	}
 
-	z.num.Mul(a.num, b.num)
-	z.denom.Mul(a.denom, b.denom)
+	z.num, z.denom = new(big.Int).Mul(a.num, b.num), new(big.Int).Mul(a.denom, b.denom)
 	return z.reduce()
 }
 
@@ -304,6 +462,9 @@ func (z *Value) mul(a, b *Value) *Value {
 //NegInf() / x == NegInf() for every positive x
 //NegInf() / x == Inf() for every negative x
 //Inf() / NegInf() == NaN()
+//x / Zero() == Inf() for every positive x including Inf()
+//x / Zero() == NegInf() for every negative x including NegInf()
+//Zero() / Zero() == NaN()
 //NaN() / x == NaN() for every x
 //NaN() / NaN() == NaN() for every x
 func (z *Value) div(a, b *Value) *Value {
@@ -317,24 +478,19 @@ func (z *Value) div(a, b *Value) *Value {
 		b.cmp(Inf()) == 0 && a.cmp(NegInf()) == 0 ||
 		a.cmp(Inf()) == 0 && b.cmp(Inf()) == 0 ||
 		b.cmp(NegInf()) == 0 && a.cmp(NegInf()) == 0 {
-		z = NaN()
-		return z
+		return z.set(NaN())
 	}
 	if a.cmp(Inf()) == 0 || a.cmp(NegInf()) == 0 {
-		if a.sign()*b.sign() > 0 {
-			z = Inf()
-			return z
+		if a.sign()*b.sign() > 0 || b.sign() == 0 && a.sign() > 0 {
+			return z.set(Inf())
 		}
-		z = NegInf()
-		return z
+		return z.set(NegInf())
 	}
 	if b.cmp(NegInf()) == 0 || b.cmp(Inf()) == 0 {
-		z = NewFrac(0, 1)
-		return z
+		return z.set(Zero())
 	}
 
-	z.num.Mul(a.num, b.denom)
-	z.denom.Mul(a.denom, b.num)
+	z.num, z.denom = new(big.Int).Mul(a.num, b.denom), new(big.Int).Mul(a.denom, b.num)
 	return z.reduce()
 }
 
@@ -344,29 +500,32 @@ func (z *Value) div(a, b *Value) *Value {
 //if a = b a.cmp(b) == 0
 //Specific comparasions:
 //
-//WARINING: NaN().cmp(x) or x.cmp(NaN()) will lead to panic for every x except of NaN
+//NaN is ordered after every other value, so cmp is a total order
 //
 //Inf().cmp(Inf()) == 0
-//Inf().cmp(x) == 1 for any x
-//x.cmp(Inf()) = -1 for any x
+//Inf().cmp(x) == 1 for any x except of NaN
+//x.cmp(Inf()) = -1 for any x except of NaN
 //NegInf().cmp(NegInf()) == 0 for any x
 //NegInf().cmp(x) == -1 for any x
 //x.cmp(NegInf()) = 1 for any x
-//NaN().cmp(NaN) == 0 for any x. Read warning above.
+//NaN().cmp(NaN) == 0
+//NaN().cmp(x) == 1 for any x except of NaN
+//x.cmp(NaN()) == -1 for any x except of NaN
 func (a Value) cmp(b *Value) int {
 	a.checkNil()
 	b.checkNil()
 
-	if a.denom.Sign() == 0 && b.denom.Sign() == 0 &&
-		a.num.Sign() == 0 && b.num.Sign() == 0 {
+	if a.IsNaN() || b.IsNaN() {
+		if !b.IsNaN() {
+			return 1
+		}
+		if !a.IsNaN() {
+			return -1
+		}
 		return 0
 	}
 
 	if a.denom.Sign() == 0 {
-		if a.num.Sign() == 0 ||
-			b.denom.Sign() == 0 && b.num.Sign() == 0 {
-			panic("Comparing with NaN")
-		}
 		if a.num.Sign() < 0 {
 			if b.denom.Sign() == 0 && b.num.Sign() < 0 {
 				return 0
@@ -380,10 +539,6 @@ func (a Value) cmp(b *Value) int {
 	}
 
 	if b.denom.Sign() == 0 {
-		if b.num.Sign() == 0 ||
-			a.denom.Sign() == 0 && a.num.Sign() == 0 {
-			panic("Comparing with NaN")
-		}
 		if b.num.Sign() < 0 {
 			if a.denom.Sign() == 0 && a.num.Sign() < 0 {
 				return 0
@@ -419,6 +574,123 @@ func (a Value) cmp(b *Value) int {
 	}
 }
 
+//Add sets z to the sum a + b and returns z.
+//Infinite and NaN operands follow the rules of add described above
+func (z *Value) Add(a, b *Value) *Value {
+	return z.set(new(Value).add(a, b))
+}
+
+//Sub sets z to the difference a - b and returns z.
+//Infinite and NaN operands follow the rules of sub described above
+func (z *Value) Sub(a, b *Value) *Value {
+	return z.set(new(Value).sub(a, b))
+}
+
+//Mul sets z to the product a * b and returns z.
+//Infinite and NaN operands follow the rules of mul described above
+func (z *Value) Mul(a, b *Value) *Value {
+	return z.set(new(Value).mul(a, b))
+}
+
+//Div sets z to the quotient a / b and returns z.
+//Infinite and NaN operands follow the rules of div described above
+func (z *Value) Div(a, b *Value) *Value {
+	return z.set(new(Value).div(a, b))
+}
+
+//Neg sets z to -x and returns z.
+//Neg(Inf()) == NegInf(), Neg(NegInf()) == Inf(), Neg(NaN()) == NaN()
+func (z *Value) Neg(x *Value) *Value {
+	z.set(x)
+	z.num.Neg(z.num)
+	return z
+}
+
+//Abs sets z to |x| and returns z.
+//Abs(NegInf()) == Inf(), Abs(NaN()) == NaN()
+func (z *Value) Abs(x *Value) *Value {
+	z.set(x)
+	z.num.Abs(z.num)
+	return z
+}
+
+//Inv sets z to 1 / x and returns z.
+//Inv(Zero()) == Inf(), Inv(Inf()) == Inv(NegInf()) == Zero(), Inv(NaN()) == NaN()
+func (z *Value) Inv(x *Value) *Value {
+	return z.set(new(Value).div(One(), x))
+}
+
+//Cmp compares a and b and returns -1, 0 or 1 if a < b, a == b or a > b.
+//Infinite values are compared as described for cmp.
+//Cmp never panics: NaN is treated as described for TotalCmp,
+//use Compare when NaN should be reported as unordered
+func (a *Value) Cmp(b *Value) int {
+	return a.cmp(b)
+}
+
+//Ordering describes result of partial comparison of two values
+type Ordering int
+
+const (
+	//Less means that a < b
+	Less Ordering = -1
+	//Equal means that a == b
+	Equal Ordering = 0
+	//Greater means that a > b
+	Greater Ordering = 1
+	//Unordered means that a or b is NaN and values can not be ordered
+	Unordered Ordering = 2
+)
+
+//String returns string representation of ordering
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "Less"
+	case Equal:
+		return "Equal"
+	case Greater:
+		return "Greater"
+	}
+	return "Unordered"
+}
+
+//Compare compares a and b in the partial order of values.
+//Returns Unordered if a or b is NaN, even when both of them are NaN
+func (a *Value) Compare(b *Value) Ordering {
+	if a.IsNaN() || b.IsNaN() {
+		return Unordered
+	}
+	return Ordering(a.cmp(b))
+}
+
+//TotalCmp compares a and b in the total order of values,
+//where NaN is equal to NaN and greater than any other value including Inf.
+//Returns -1, 0 or 1 if a < b, a == b or a > b
+func (a *Value) TotalCmp(b *Value) int {
+	return a.cmp(b)
+}
+
+//Sign returns -1, 0 or 1 if v < 0, v == 0 or v > 0.
+//Sign of NaN is 0
+func (v *Value) Sign() int {
+	return v.sign()
+}
+
+//IsInf reports whether v is Inf() or NegInf()
+func (v *Value) IsInf() bool {
+	v.checkNil()
+
+	return v.denom.Sign() == 0 && v.num.Sign() != 0
+}
+
+//IsNaN reports whether v is NaN()
+func (v *Value) IsNaN() bool {
+	v.checkNil()
+
+	return v.denom.Sign() == 0 && v.num.Sign() == 0
+}
+
 func (v *Value) checkNil() {
 	if v.num == nil {
 		v.num = new(big.Int)
@@ -430,13 +702,5 @@ func (v *Value) checkNil() {
 }
 
 func nod(a *big.Int, b *big.Int) *big.Int {
-	r := big.NewInt(1)
-	prevR := new(big.Int).Set(r)
-	for r.Sign() != 0 {
-		prevR.Set(r)
-		r.Mod(a, b)
-		a = b
-		b = new(big.Int).Set(r)
-	}
-	return prevR
+	return new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
 }
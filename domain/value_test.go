@@ -1,6 +1,10 @@
 package domain
 
-import "testing"
+import (
+	"math"
+	"math/big"
+	"testing"
+)
 
 func TestValueCmp(t *testing.T) {
 	sign := func(a int) int {
@@ -382,3 +386,252 @@ func TestValueOperations(t *testing.T) {
 		}
 	}
 }
+
+func TestValueExportedOperations(t *testing.T) {
+	a := NewFrac(12, 7)
+	var testPairs = []struct {
+		operation *Value
+		res       *Value
+	}{
+		{
+			operation: new(Value).Add(NewFrac(12, 7), NewFrac(13, 9)),
+			res:       NewFrac(199, 63),
+		},
+		{
+			operation: new(Value).Sub(NewFrac(12, 7), NewFrac(13, 9)),
+			res:       NewFrac(17, 63),
+		},
+		{
+			operation: new(Value).Mul(NewFrac(12, 7), NewFrac(-13, 9)),
+			res:       NewFrac(-52, 21),
+		},
+		{
+			operation: new(Value).Div(NewFrac(12, 7), NewFrac(13, 9)),
+			res:       NewFrac(108, 91),
+		},
+		{
+			operation: new(Value).Div(NewFrac(12, 7), Zero()),
+			res:       Inf(),
+		},
+		{
+			operation: new(Value).Div(NewFrac(-12, 7), Zero()),
+			res:       NegInf(),
+		},
+		{
+			operation: new(Value).Div(Zero(), Zero()),
+			res:       NaN(),
+		},
+		{
+			operation: new(Value).Mul(Inf(), Zero()),
+			res:       NaN(),
+		},
+		{
+			operation: new(Value).Mul(Zero(), NegInf()),
+			res:       NaN(),
+		},
+		{
+			operation: new(Value).Mul(Inf(), NegInf()),
+			res:       NegInf(),
+		},
+		{
+			operation: new(Value).Neg(NewFrac(12, 7)),
+			res:       NewFrac(-12, 7),
+		},
+		{
+			operation: new(Value).Neg(Inf()),
+			res:       NegInf(),
+		},
+		{
+			operation: new(Value).Abs(NewFrac(-12, 7)),
+			res:       NewFrac(12, 7),
+		},
+		{
+			operation: new(Value).Abs(NegInf()),
+			res:       Inf(),
+		},
+		{
+			operation: new(Value).Inv(NewFrac(-12, 7)),
+			res:       NewFrac(-7, 12),
+		},
+		{
+			operation: new(Value).Inv(Zero()),
+			res:       Inf(),
+		},
+		{
+			operation: new(Value).Inv(NegInf()),
+			res:       Zero(),
+		},
+		{
+			operation: a.Add(a, a),
+			res:       NewFrac(24, 7),
+		},
+		{
+			operation: NewFrac(10, 5),
+			res:       NewInt(2),
+		},
+	}
+	for i, pair := range testPairs {
+		if pair.operation.Cmp(pair.res) != 0 {
+			t.Errorf("In pair %d: %s should be equal %s", i, pair.operation, pair.res)
+		}
+	}
+	if s := NewFrac(10, -4).String(); s != "-5 / 2" {
+		t.Errorf("NewFrac(10, -4) should be -5 / 2, got %s", s)
+	}
+}
+
+func TestValuePredicates(t *testing.T) {
+	var testPairs = []struct {
+		a     *Value
+		sign  int
+		isInf bool
+		isNaN bool
+	}{
+		{a: NewFrac(-3, 4), sign: -1},
+		{a: NewFrac(3, -4), sign: -1},
+		{a: NewFrac(0, -4), sign: 0},
+		{a: NewFrac(3, 4), sign: 1},
+		{a: Inf(), sign: 1, isInf: true},
+		{a: NegInf(), sign: -1, isInf: true},
+		{a: NaN(), sign: 0, isNaN: true},
+	}
+	for i, pair := range testPairs {
+		if pair.a.Sign() != pair.sign {
+			t.Errorf("In pair %d: sign of %s should be %d", i, pair.a, pair.sign)
+		}
+		if pair.a.IsInf() != pair.isInf {
+			t.Errorf("In pair %d: IsInf of %s should be %t", i, pair.a, pair.isInf)
+		}
+		if pair.a.IsNaN() != pair.isNaN {
+			t.Errorf("In pair %d: IsNaN of %s should be %t", i, pair.a, pair.isNaN)
+		}
+	}
+}
+
+func TestValueCompare(t *testing.T) {
+	var testPairs = []struct {
+		a        *Value
+		b        *Value
+		ordering Ordering
+		total    int
+	}{
+		{a: NewFrac(1, 2), b: NewFrac(2, 3), ordering: Less, total: -1},
+		{a: NewFrac(2, 3), b: NewFrac(1, 2), ordering: Greater, total: 1},
+		{a: NewFrac(2, 4), b: NewFrac(1, 2), ordering: Equal, total: 0},
+		{a: NegInf(), b: Inf(), ordering: Less, total: -1},
+		{a: NaN(), b: NewFrac(1, 2), ordering: Unordered, total: 1},
+		{a: NewFrac(1, 2), b: NaN(), ordering: Unordered, total: -1},
+		{a: Inf(), b: NaN(), ordering: Unordered, total: -1},
+		{a: NaN(), b: NaN(), ordering: Unordered, total: 0},
+	}
+	for i, pair := range testPairs {
+		if o := pair.a.Compare(pair.b); o != pair.ordering {
+			t.Errorf("In pair %d: %s compared with %s should be %s, got %s", i, pair.a, pair.b, pair.ordering, o)
+		}
+		if c := pair.a.TotalCmp(pair.b); c != pair.total {
+			t.Errorf("In pair %d: total comparison of %s and %s should be %d, got %d", i, pair.a, pair.b, pair.total, c)
+		}
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	huge := "123456789012345678901234567890123456789"
+	hugeInt, _ := new(big.Int).SetString(huge, 10)
+	var testPairs = []struct {
+		s   string
+		res *Value
+	}{
+		{s: "35/6", res: NewFrac(35, 6)},
+		{s: "35 / 6", res: NewFrac(35, 6)},
+		{s: " -35 / 6 ", res: NewFrac(-35, 6)},
+		{s: "-0.125", res: NewFrac(-1, 8)},
+		{s: "1.5e-7", res: NewFrac(15, 100000000)},
+		{s: "2.5E2", res: NewInt(250)},
+		{s: "0.5/0.25", res: NewInt(2)},
+		{s: "Inf", res: Inf()},
+		{s: "+Inf", res: Inf()},
+		{s: "-Inf", res: NegInf()},
+		{s: "NaN", res: NaN()},
+		{s: "1/0", res: Inf()},
+		{s: huge, res: &Value{num: hugeInt, denom: big.NewInt(1)}},
+	}
+	for i, pair := range testPairs {
+		v, err := ParseValue(pair.s)
+		if err != nil {
+			t.Errorf("In pair %d: unexpected error %v", i, err)
+			continue
+		}
+		if v.cmp(pair.res) != 0 {
+			t.Errorf("In pair %d: %q should be parsed to %s, got %s", i, pair.s, pair.res, v)
+		}
+	}
+	for i, s := range []string{"", "-", "abc", "1/2/3", "0x10", "1..2", "/2", "Infinity", "--1", "1 2"} {
+		if v, err := ParseValue(s); err == nil {
+			t.Errorf("In bad pair %d: %q should not be parsed, got %s", i, s, v)
+		}
+	}
+	v := NewFrac(1, 2)
+	if err := v.UnmarshalText([]byte("abc")); err == nil || v.cmp(NewFrac(1, 2)) != 0 {
+		t.Errorf("Failed UnmarshalText should keep value unchanged, got %s", v)
+	}
+	if err := v.UnmarshalText([]byte("-7 / 3")); err != nil || v.cmp(NewFrac(-7, 3)) != 0 {
+		t.Errorf("UnmarshalText should set value to -7 / 3, got %s", v)
+	}
+}
+
+func TestNewFloat(t *testing.T) {
+	var testPairs = []struct {
+		v   *Value
+		res string
+	}{
+		{v: NewFloat(0.1), res: "1 / 10"},
+		{v: NewFloat(-2.5), res: "-5 / 2"},
+		{v: NewFloat(5), res: "5"},
+		{v: NewFloat(math.NaN()), res: "NaN"},
+		{v: NewFloat(math.Inf(1)), res: "Inf"},
+		{v: NewFloat(math.Inf(-1)), res: "-Inf"},
+		{v: NewFloatExact(0.1), res: "3602879701896397 / 36028797018963968"},
+		{v: NewFloatExact(-0.5), res: "-1 / 2"},
+		{v: NewFloatExact(5), res: "5"},
+		{v: NewFloatExact(math.NaN()), res: "NaN"},
+		{v: NewFloatExact(math.Inf(-1)), res: "-Inf"},
+	}
+	for i, pair := range testPairs {
+		if pair.v.String() != pair.res {
+			t.Errorf("In pair %d: %s should be equal %s", i, pair.v, pair.res)
+		}
+	}
+}
+
+func TestValueFloat64(t *testing.T) {
+	huge := new(Value).Mul(NewFloatExact(math.MaxFloat64), NewInt(2))
+	var testPairs = []struct {
+		v     *Value
+		mode  RoundingMode
+		res   float64
+		exact bool
+	}{
+		{v: NewFrac(1, 2), mode: RoundDown, res: 0.5, exact: true},
+		{v: NewFrac(1, 10), mode: RoundNearest, res: 0.1},
+		{v: NewFrac(1, 10), mode: RoundDown, res: math.Nextafter(0.1, 0)},
+		{v: NewFrac(1, 10), mode: RoundUp, res: 0.1},
+		{v: NewFrac(1, 3), mode: RoundDown, res: 1.0 / 3},
+		{v: NewFrac(1, 3), mode: RoundUp, res: math.Nextafter(1.0/3, 1)},
+		{v: NewFrac(-1, 10), mode: RoundDown, res: -0.1},
+		{v: NewFrac(-1, 10), mode: RoundUp, res: math.Nextafter(-0.1, 0)},
+		{v: huge, mode: RoundDown, res: math.MaxFloat64},
+		{v: huge, mode: RoundUp, res: math.Inf(1)},
+		{v: new(Value).Neg(huge), mode: RoundUp, res: -math.MaxFloat64},
+		{v: Inf(), mode: RoundDown, res: math.Inf(1), exact: true},
+		{v: NegInf(), mode: RoundUp, res: math.Inf(-1), exact: true},
+	}
+	for i, pair := range testPairs {
+		res, exact := pair.v.Float64(pair.mode)
+		if res != pair.res || exact != pair.exact {
+			t.Errorf("In pair %d: %s should be converted to %v (exact %t), got %v (exact %t)", i, pair.v, pair.res, pair.exact, res, exact)
+		}
+	}
+	if res, _ := NaN().Float64(RoundNearest); !math.IsNaN(res) {
+		t.Errorf("NaN should be converted to NaN, got %v", res)
+	}
+}
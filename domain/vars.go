@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//ErrCyclicSubstitution is returned by Substitute if variable is substituted with expression depending on itself
+var ErrCyclicSubstitution = errors.New("cyclic substitution")
+
+//ErrNoExpression is returned by Eval, Substitute and Polynomial on zero Interval, which has no expression
+var ErrNoExpression = errors.New("interval has no expression")
+
+//VarError is returned by Eval if some variables of expression are not bound in VarMap
+//or VarMap binds variables which are not used in expression. Names are sorted
+type VarError struct {
+	Unbound []string
+	Unused  []string
+}
+
+//Error returns error message listing unbound and unused variables
+func (e *VarError) Error() string {
+	var parts []string
+	if len(e.Unbound) != 0 {
+		parts = append(parts, "unbound variables: "+strings.Join(e.Unbound, ", "))
+	}
+	if len(e.Unused) != 0 {
+		parts = append(parts, "unused variables: "+strings.Join(e.Unused, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+//Vars returns sorted names of variables used in interval
+func (i Interval) Vars() []string {
+	names := map[string]bool{}
+	collectVars(i.op, names)
+	return sortedNames(names)
+}
+
+func collectVars(op operation, names map[string]bool) {
+	if op == nil {
+		return
+	}
+	if v, ok := op.(variable); ok {
+		names[v.varName] = true
+		return
+	}
+	for _, child := range op.children() {
+		collectVars(child, names)
+	}
+}
+
+func sortedNames(names map[string]bool) []string {
+	var res []string
+	for name := range names {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+//Eval solves interval with variable values passed in VarMap like Solve,
+//but every variable of interval should be bound to interval without variables and every bound variable should be used.
+//Returns *VarError naming all unbound and unused variables otherwise and ErrNoExpression for zero Interval
+func (i Interval) Eval(varMap VarMap) (Interval, error) {
+	if err := i.checkVars(varMap); err != nil {
+		return Interval{}, err
+	}
+	res := i.Solve(varMap)
+	if vars := res.Vars(); len(vars) != 0 {
+		//bound values themselves contain variables
+		return Interval{}, &VarError{Unbound: vars}
+	}
+	return res, nil
+}
+
+//checkVars returns ErrNoExpression for zero Interval and *VarError if some variables of interval are not bound in varMap or bound variables are not used
+func (i Interval) checkVars(varMap VarMap) error {
+	if i.op == nil {
+		return ErrNoExpression
+	}
+	used := map[string]bool{}
+	collectVars(i.op, used)
+	unbound, unused := map[string]bool{}, map[string]bool{}
+	for name := range used {
+		if varMap[name].op == nil {
+			unbound[name] = true
+		}
+	}
+	for name, value := range varMap {
+		if !used[name] && value.op != nil {
+			unused[name] = true
+		}
+	}
+	if len(unbound) != 0 || len(unused) != 0 {
+		return &VarError{Unbound: sortedNames(unbound), Unused: sortedNames(unused)}
+	}
+	return nil
+}
+
+//Substitute replaces variables of interval with expressions from passed map and returns composed expression,
+//for example x + [1, 1] with x replaced with y * z becomes y * z + [1, 1].
+//Variables of substituted expressions are substituted too, so substitution of x with y and y with [1, 2]
+//replaces x with [1, 2]. Result is not folded, use Solve to fold it.
+//Returns error wrapping ErrCyclicSubstitution if variable depends on itself through substitutions
+//and ErrNoExpression for zero Interval
+func (i Interval) Substitute(exprs map[string]Interval) (Interval, error) {
+	if i.op == nil {
+		return Interval{}, ErrNoExpression
+	}
+	s := substitution{exprs: exprs, resolved: map[string]operation{}}
+	op, err := s.substitute(i.op, nil)
+	if err != nil {
+		return Interval{}, err
+	}
+	return Interval{op: op}, nil
+}
+
+//substitution keeps expressions with already substituted variables
+type substitution struct {
+	exprs    map[string]Interval
+	resolved map[string]operation
+}
+
+//substitute replaces variables in op, path is chain of variables which are being substituted now
+func (s substitution) substitute(op operation, path []string) (operation, error) {
+	if v, ok := op.(variable); ok {
+		return s.resolve(v, path)
+	}
+	children := op.children()
+	if len(children) == 0 {
+		return op, nil
+	}
+	substituted := make([]operation, len(children))
+	for j, child := range children {
+		res, err := s.substitute(child, path)
+		if err != nil {
+			return nil, err
+		}
+		substituted[j] = res
+	}
+	return op.withChildren(substituted), nil
+}
+
+func (s substitution) resolve(v variable, path []string) (operation, error) {
+	if res, ok := s.resolved[v.varName]; ok {
+		return res, nil
+	}
+	expr := s.exprs[v.varName]
+	if expr.op == nil {
+		return v, nil
+	}
+	for j, name := range path {
+		if name == v.varName {
+			cycle := append(append([]string{}, path[j:]...), name)
+			return nil, fmt.Errorf("%w: %s", ErrCyclicSubstitution, strings.Join(cycle, " -> "))
+		}
+	}
+	res, err := s.substitute(expr.op, append(path, v.varName))
+	if err != nil {
+		return nil, err
+	}
+	s.resolved[v.varName] = res
+	return res, nil
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIntervalVars(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	z, _ := Var("z")
+	var testPairs = []struct {
+		interval Interval
+		vars     []string
+	}{
+		{NewInterval(One(), One()), nil},
+		{Interval{}, nil},
+		{x, []string{"x"}},
+		{z.Add(x.Div(y)).Sub(x), []string{"x", "y", "z"}},
+		{Max(y.Sin(), x.Pow(2).Sqrt()).Clamp(z, x), []string{"x", "y", "z"}},
+		{x.Mul(y).Solve(VarMap{"x": NewInterval(One(), NewInt(2))}), []string{"y"}},
+	}
+	for i, pair := range testPairs {
+		if vars := pair.interval.Vars(); !reflect.DeepEqual(vars, pair.vars) {
+			t.Errorf("In pair %d: variables of %s should be %v, got %v", i, pair.interval, pair.vars, vars)
+		}
+	}
+}
+
+func TestIntervalEval(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	expr := x.Add(y.Mul(x))
+	one := NewInterval(One(), One())
+	var testPairs = []struct {
+		varMap VarMap
+		res    string
+		err    string
+	}{
+		{
+			varMap: VarMap{"x": NewInterval(One(), NewInt(2)), "y": one},
+			res:    "[2, 4]",
+		},
+		{
+			varMap: VarMap{"u": one, "y": one},
+			err:    "unbound variables: x; unused variables: u",
+		},
+		{
+			varMap: VarMap{},
+			err:    "unbound variables: x, y",
+		},
+		{
+			varMap: VarMap{"x": one, "y": one, "b": one, "a": one},
+			err:    "unused variables: a, b",
+		},
+		{
+			varMap: VarMap{"x": one, "y": Interval{}},
+			err:    "unbound variables: y",
+		},
+		{
+			varMap: VarMap{"x": one, "y": x.Add(one)},
+			err:    "unbound variables: x",
+		},
+	}
+	for i, pair := range testPairs {
+		res, err := expr.Eval(pair.varMap)
+		if pair.err != "" {
+			if _, ok := err.(*VarError); !ok || err.Error() != pair.err {
+				t.Errorf("In pair %d: Eval should fail with %q, got %v", i, pair.err, err)
+			}
+			continue
+		}
+		if err != nil || res.String() != pair.res {
+			t.Errorf("In pair %d: Eval should return %s, got %s, %v", i, pair.res, res, err)
+		}
+	}
+}
+
+func TestIntervalEvalZero(t *testing.T) {
+	x, _ := Var("x")
+	varMap := VarMap{"x": NewInterval(One(), One())}
+	if _, err := (Interval{}).Eval(VarMap{}); err != ErrNoExpression {
+		t.Errorf("Eval of zero interval should fail with %v, got %v", ErrNoExpression, err)
+	}
+	if _, err := (Interval{}).Eval(varMap); err != ErrNoExpression {
+		t.Errorf("Eval of zero interval should fail with %v, got %v", ErrNoExpression, err)
+	}
+	if _, err := (Interval{}).EvalGradient(varMap); err != ErrNoExpression {
+		t.Errorf("EvalGradient of zero interval should fail with %v, got %v", ErrNoExpression, err)
+	}
+	if _, err := (Interval{}).EvalCentered(varMap); err != ErrNoExpression {
+		t.Errorf("EvalCentered of zero interval should fail with %v, got %v", ErrNoExpression, err)
+	}
+	if _, err := (Interval{}).EvalMonotonic(varMap); err != ErrNoExpression {
+		t.Errorf("EvalMonotonic of zero interval should fail with %v, got %v", ErrNoExpression, err)
+	}
+	if _, err := x.Eval(VarMap{"x": Interval{}}); err == nil {
+		t.Errorf("Eval with x bound to zero interval should fail")
+	}
+}
+
+func TestIntervalSubstituteZero(t *testing.T) {
+	x, _ := Var("x")
+	if _, err := (Interval{}).Substitute(map[string]Interval{"x": x}); err != ErrNoExpression {
+		t.Errorf("Substitute in zero interval should fail with %v, got %v", ErrNoExpression, err)
+	}
+	if res, err := x.Substitute(map[string]Interval{"x": {}}); err != nil || res.String() != "x" {
+		t.Errorf("Substitute of x with zero interval should keep x, got %s, %v", res, err)
+	}
+}
+
+func TestIntervalSubstitute(t *testing.T) {
+	x, _ := Var("x")
+	y, _ := Var("y")
+	z, _ := Var("z")
+	one := NewInterval(One(), One())
+	var testPairs = []struct {
+		interval Interval
+		exprs    map[string]Interval
+		res      string
+		solved   string
+	}{
+		{
+			interval: x.Add(one),
+			exprs:    map[string]Interval{"x": y.Mul(z)},
+			res:      "[1, 1] + y * z",
+			solved:   "[1, 1] + y * z",
+		},
+		{
+			interval: x.Mul(x).Sub(y),
+			exprs:    map[string]Interval{"x": y.Add(NewInterval(One(), NewInt(2))), "y": z.Pow(2)},
+			res:      "([1, 2] + z^2) * ([1, 2] + z^2) + ([0, 0] - z^2)",
+			solved:   "([1, 2] + z^2) * ([1, 2] + z^2) - z^2",
+		},
+		{
+			interval: x.Sin().Add(y),
+			exprs:    map[string]Interval{"x": y, "y": NewInterval(Zero(), Zero())},
+			res:      "sin([0, 0]) + [0, 0]",
+			solved:   "[0, 0]",
+		},
+		{
+			interval: Max(x, z).Clamp(y, z),
+			exprs:    map[string]Interval{"z": one, "u": x},
+			res:      "clamp(max(x, [1, 1]), y, [1, 1])",
+			solved:   "clamp(max([1, 1], x), y, [1, 1])",
+		},
+		{
+			interval: x.Div(y),
+			exprs:    nil,
+			res:      "x * ([1, 1] / y)",
+			solved:   "x / y",
+		},
+	}
+	for i, pair := range testPairs {
+		res, err := pair.interval.Substitute(pair.exprs)
+		if err != nil || res.String() != pair.res {
+			t.Errorf("In pair %d: substitution should be %s, got %s, %v", i, pair.res, res, err)
+			continue
+		}
+		if solved := res.Solve(VarMap{}).String(); solved != pair.solved {
+			t.Errorf("In pair %d: %s should be solved to %s, got %s", i, res, pair.solved, solved)
+		}
+	}
+
+	var cyclePairs = []struct {
+		exprs map[string]Interval
+		err   string
+	}{
+		{map[string]Interval{"x": x.Add(one)}, "cyclic substitution: x -> x"},
+		{map[string]Interval{"x": y, "y": z.Mul(x)}, "cyclic substitution: y -> x -> y"},
+		{map[string]Interval{"y": z, "z": y}, "cyclic substitution: y -> z -> y"},
+	}
+	for i, pair := range cyclePairs {
+		_, err := x.Add(y).Substitute(pair.exprs)
+		if !errors.Is(err, ErrCyclicSubstitution) || err.Error() != pair.err {
+			t.Errorf("In pair %d: substitution should fail with %q, got %v", i, pair.err, err)
+		}
+	}
+}
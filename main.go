@@ -18,10 +18,14 @@ func main() {
 				variable,
 			),
 		).Mul(
-			domain.NewInterval(domain.NewFrac(5, 3), domain.NewFrac(1, 2)),
+			domain.NewInterval(domain.NewFrac(1, 2), domain.NewFrac(5, 3)),
 		).Div(
 			variable,
 		),
 	).Sub(domain.NewInterval(domain.NewFrac(1, 1), domain.NewFrac(1, 1)))
-	fmt.Println(op.Solve(domain.VarMap{"u": domain.NewInterval(domain.NewFrac(1, 1), domain.NewFrac(1, 1))}).String())
+	res, err := op.Eval(domain.VarMap{"x": domain.NewInterval(domain.NewFrac(1, 1), domain.NewFrac(1, 1))})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(res.String())
 }